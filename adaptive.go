@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+	"github.com/olekukonko/tablewriter"
+)
+
+// AdaptiveRRSchedule is round-robin whose quantum is recalculated at the
+// start of every cycle as the median remaining burst of the processes then
+// ready (the lower of the two middle values for an even count), so the
+// quantum shrinks as short processes dominate and grows when only long ones
+// are left. Processes arriving during a cycle join the next one. Each
+// cycle's quantum is recorded in the trace. Like RRSchedule it emits one row
+// per slice.
+func AdaptiveRRSchedule(ctx context.Context, processes []Process, limits Limits) Result {
+	pending := copyProcesses(processes)
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].ArrivalTime < pending[j].ArrivalTime
+	})
+
+	var (
+		now   int64
+		ready []*job
+		rows  = make([]Row, 0, len(processes))
+		gantt = make([]TimeSlice, 0)
+		trace = make([]TraceEvent, 0)
+	)
+	for cycle := 1; len(pending) > 0 || len(ready) > 0; cycle++ {
+		if halted(ctx, limits, now, len(gantt)) {
+			break
+		}
+		for len(pending) > 0 && pending[0].ArrivalTime <= now {
+			p := pending[0]
+			pending = pending[1:]
+			if p.BurstDuration == 0 {
+				// Nothing to run; finishing it now keeps it out of the
+				// median, which would otherwise give a quantum of 0.
+				rows = append(rows, Row{
+					PID:        p.ProcessID,
+					Priority:   p.Priority,
+					Arrival:    p.ArrivalTime,
+					Wait:       now - p.ArrivalTime,
+					Turnaround: now - p.ArrivalTime,
+					Exit:       now,
+				})
+				continue
+			}
+			ready = append(ready, &job{Process: p, remaining: p.BurstDuration})
+		}
+		if len(ready) == 0 {
+			if len(pending) > 0 {
+				now = pending[0].ArrivalTime
+			}
+			cycle--
+			continue
+		}
+
+		quantum := medianRemaining(ready)
+		trace = append(trace, TraceEvent{
+			Time:   now,
+			Event:  "quantum",
+			Detail: fmt.Sprintf("cycle %d: %d (median of %d remaining)", cycle, quantum, len(ready)),
+		})
+
+		unfinished := ready[:0]
+		for _, j := range ready {
+			if halted(ctx, limits, now, len(gantt)) {
+				unfinished = append(unfinished, j)
+				continue
+			}
+			want := quantum
+			if j.remaining < want {
+				want = j.remaining
+			}
+			start := now
+			burst, stop, killed := runFor(j.Process, start, want)
+			if burst == 0 {
+				// Killed while waiting; it never held the CPU.
+				start = stop
+			} else {
+				now = stop
+				gantt = append(gantt, TimeSlice{PID: j.ProcessID, Start: start, Stop: stop})
+			}
+			rows = append(rows, Row{
+				PID:        j.ProcessID,
+				Priority:   j.Priority,
+				Burst:      burst,
+				Arrival:    j.ArrivalTime,
+				Wait:       start - j.ArrivalTime,
+				Turnaround: stop - j.ArrivalTime,
+				Exit:       stop,
+				Killed:     killed,
+			})
+			j.remaining -= burst
+			if j.remaining > 0 && !killed {
+				unfinished = append(unfinished, j)
+			}
+		}
+		ready = unfinished
+	}
+
+	return Result{Rows: rows, Gantt: gantt, Trace: trace, Stats: summarize(rows)}
+}
+
+// medianRemaining is the lower median of the remaining bursts in ready.
+func medianRemaining(ready []*job) int64 {
+	remaining := make([]int64, len(ready))
+	for i, j := range ready {
+		remaining[i] = j.remaining
+	}
+	sort.Slice(remaining, func(a, b int) bool { return remaining[a] < remaining[b] })
+
+	return remaining[(len(remaining)-1)/2]
+}
+
+// AdaptiveRRReport outputs fixed-quantum round-robin next to round-robin
+// with the median quantum, showing what adapting the quantum changes.
+// RRSchedule reports each slice's start as its wait, so its average is
+// restated from the arrival to match the adaptive one.
+func AdaptiveRRReport(ctx context.Context, w io.Writer, processes []Process, limits Limits) {
+	fixed := Scheduler{Schedule: RRSchedule}.Run(ctx, processes, limits)
+	adaptive := Scheduler{Schedule: AdaptiveRRSchedule}.Run(ctx, processes, limits)
+	fixed.Stats.AveWait = waitSinceArrival(fixed.Rows)
+
+	row := func(name string, r Result) []string {
+		return []string{
+			name,
+			fmt.Sprintf("%.2f", r.Stats.AveWait),
+			fmt.Sprintf("%.2f", r.Stats.AveTurnaround),
+			fmt.Sprintf("%.2f/t", r.Stats.Throughput),
+			fmt.Sprint(contextSwitches(r.Gantt)),
+		}
+	}
+
+	render.Title(w, "Round-robin quantum")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Quantum", "Wait", "Turnaround", "Throughput", "Context switches"})
+	table.Append(row("fixed at 3", fixed))
+	table.Append(row(fmt.Sprintf("median, %d cycles", len(adaptive.Trace)), adaptive))
+	table.SetFooter([]string{"Change",
+		fmt.Sprintf("%+.2f", adaptive.Stats.AveWait-fixed.Stats.AveWait),
+		fmt.Sprintf("%+.2f", adaptive.Stats.AveTurnaround-fixed.Stats.AveTurnaround),
+		fmt.Sprintf("%+.2f/t", adaptive.Stats.Throughput-fixed.Stats.Throughput),
+		fmt.Sprintf("%+d", contextSwitches(adaptive.Gantt)-contextSwitches(fixed.Gantt))})
+	table.Render()
+}
+
+// waitSinceArrival averages the time from each row's arrival to the start of
+// its slice.
+func waitSinceArrival(rows []Row) float64 {
+	if len(rows) == 0 {
+		return 0
+	}
+	var total float64
+	for _, r := range rows {
+		total += float64(r.Start() - r.Arrival)
+	}
+
+	return total / float64(len(rows))
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAdaptiveRRSchedule(t *testing.T) {
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+		empty     int
+	}{
+		{
+			// Quanta of 4, then the median 2 of {3, 2}, then 1.
+			name:      "quantum follows the median",
+			processes: workload,
+			want:      []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}, {PID: 3, Start: 6, Stop: 8}, {PID: 2, Start: 8, Stop: 9}},
+		},
+		{
+			// The empty processes finish on arrival rather than making
+			// the median 0.
+			name: "zero bursts",
+			processes: []Process{
+				{ProcessID: 1},
+				{ProcessID: 2},
+				{ProcessID: 3, BurstDuration: 4},
+			},
+			want:  []TimeSlice{{PID: 3, Start: 0, Stop: 4}},
+			empty: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Scheduler{Schedule: AdaptiveRRSchedule}.Run(context.Background(), tt.processes, Limits{})
+			if !reflect.DeepEqual(r.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.want)
+			}
+			empty := 0
+			for _, row := range r.Rows {
+				if row.Burst == 0 {
+					empty++
+				}
+			}
+			if empty != tt.empty {
+				t.Errorf("%d rows with no burst, want %d", empty, tt.empty)
+			}
+		})
+	}
+}
+
+func TestAdaptiveRRTracesQuanta(t *testing.T) {
+	r := AdaptiveRRSchedule(context.Background(), workload, Limits{})
+	var quanta []string
+	for _, e := range r.Trace {
+		if e.Event == "quantum" {
+			quanta = append(quanta, e.Detail)
+		}
+	}
+	want := []string{
+		"cycle 1: 4 (median of 1 remaining)",
+		"cycle 2: 2 (median of 2 remaining)",
+		"cycle 3: 1 (median of 1 remaining)",
+	}
+	if !reflect.DeepEqual(quanta, want) {
+		t.Errorf("quanta = %q, want %q", quanta, want)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// AgingPrioritySchedule returns a non-preemptive priority scheduler with
+// aging: a waiting process moves up one priority level (lower numbers run
+// first) for every interval time units it has been waiting, so low-priority
+// processes can't starve. Each slice is noted with the effective priority it
+// was dispatched at, and the trace records every change of a waiting
+// process's effective priority.
+func AgingPrioritySchedule(interval int64) func(ctx context.Context, processes []Process, limits Limits) Result {
+	return func(ctx context.Context, processes []Process, limits Limits) Result {
+		pending := copyProcesses(processes)
+		sort.SliceStable(pending, func(i, j int) bool {
+			return pending[i].ArrivalTime < pending[j].ArrivalTime
+		})
+
+		var (
+			now       int64
+			ready     []Process
+			effective = make(map[int64]int64)
+			rows      = make([]Row, 0, len(processes))
+			gantt     = make([]TimeSlice, 0)
+			trace     = make([]TraceEvent, 0)
+		)
+		for len(pending) > 0 || len(ready) > 0 {
+			if halted(ctx, limits, now, len(gantt)) {
+				break
+			}
+			for len(pending) > 0 && pending[0].ArrivalTime <= now {
+				p := pending[0]
+				ready = append(ready, p)
+				effective[p.ProcessID] = p.Priority
+				trace = append(trace, TraceEvent{
+					Time:   p.ArrivalTime,
+					PID:    p.ProcessID,
+					Event:  "arrive",
+					Detail: fmt.Sprintf("priority %d", p.Priority),
+				})
+				pending = pending[1:]
+			}
+			if len(ready) == 0 {
+				now = pending[0].ArrivalTime
+				continue
+			}
+
+			next := 0
+			for i, p := range ready {
+				aged := agedPriority(p, now, interval)
+				if aged != effective[p.ProcessID] {
+					trace = append(trace, TraceEvent{
+						Time:   now,
+						PID:    p.ProcessID,
+						Event:  "age",
+						Detail: fmt.Sprintf("priority %d → %d", effective[p.ProcessID], aged),
+					})
+					effective[p.ProcessID] = aged
+				}
+				if aged < effective[ready[next].ProcessID] {
+					next = i
+				}
+			}
+			process := ready[next]
+			ready = append(ready[:next], ready[next+1:]...)
+
+			start := now
+			burst, stop, killed := runFor(process, start, process.BurstDuration)
+			if burst == 0 {
+				// Killed while waiting; it never held the CPU.
+				start = stop
+			} else {
+				now = stop
+				trace = append(trace, TraceEvent{
+					Time:   start,
+					PID:    process.ProcessID,
+					Event:  "dispatch",
+					Detail: fmt.Sprintf("priority %d", effective[process.ProcessID]),
+				})
+				gantt = append(gantt, TimeSlice{
+					PID:   process.ProcessID,
+					Start: start,
+					Stop:  stop,
+					Note:  fmt.Sprintf("p%d", effective[process.ProcessID]),
+				})
+			}
+			event := "exit"
+			if killed {
+				event = "killed"
+			}
+			trace = append(trace, TraceEvent{Time: stop, PID: process.ProcessID, Event: event})
+
+			rows = append(rows, Row{
+				PID:        process.ProcessID,
+				Priority:   process.Priority,
+				Burst:      burst,
+				Arrival:    process.ArrivalTime,
+				Wait:       start - process.ArrivalTime,
+				Turnaround: stop - process.ArrivalTime,
+				Exit:       stop,
+				Killed:     killed,
+			})
+		}
+
+		// Arrivals are only picked up at the next dispatch, so put them back
+		// in time order.
+		sort.SliceStable(trace, func(i, j int) bool {
+			return trace[i].Time < trace[j].Time
+		})
+
+		return Result{Rows: rows, Gantt: gantt, Trace: trace, Stats: summarize(rows)}
+	}
+}
+
+// agedPriority is p's priority after waiting from its arrival until now,
+// improved by a level per interval but never past level 0, or past its own
+// level if that is already better.
+func agedPriority(p Process, now, interval int64) int64 {
+	floor := int64(0)
+	if p.Priority < floor {
+		floor = p.Priority
+	}
+	aged := p.Priority - (now-p.ArrivalTime)/interval
+	if aged < floor {
+		aged = floor
+	}
+
+	return aged
+}
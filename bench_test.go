@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+	"github.com/jonuorah26/CSCE4600-Project1/sim"
+)
+
+// syntheticTrace writes n processes as CSV, arriving 0 to 13 time units
+// apart with bursts of 1 to 10, so the CPU is about 85% loaded: busy, but
+// with a queue that keeps draining.
+func syntheticTrace(n int) []byte {
+	rng := rand.New(rand.NewSource(1))
+	var buf bytes.Buffer
+	var arrival int64
+	for pid := 1; pid <= n; pid++ {
+		arrival += rng.Int63n(14)
+		_, _ = fmt.Fprintf(&buf, "%d,%d,%d,%d\n", pid, 1+rng.Int63n(10), arrival, rng.Int63n(6))
+	}
+
+	return buf.Bytes()
+}
+
+// BenchmarkLargeTrace loads a million-process trace, runs every default
+// scheduler over it and outputs the results, as a run from the command line
+// does.
+func BenchmarkLargeTrace(b *testing.B) {
+	trace := syntheticTrace(1000000)
+	csv, err := sim.LoaderFor("csv", "")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, format := range []string{"table", "json"} {
+		b.Run(format, func(b *testing.B) {
+			b.SetBytes(int64(len(trace)))
+			for i := 0; i < b.N; i++ {
+				processes, err := csv.Load(bytes.NewReader(trace))
+				if err != nil {
+					b.Fatal(err)
+				}
+				params := Params{Seed: 1, CPUs: 1, Placement: "jsq"}
+				out := bufio.NewWriter(io.Discard)
+				emit, err := EmitterFor(format, out)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for _, s := range buildSchedulers(processes, params) {
+					r := s.Run(context.Background(), processes, params.Limits())
+					if emit != nil {
+						emit.Add(Envelope{SchemaVersion: SchemaVersion, Algorithm: s.Title, Params: params, Result: r})
+						continue
+					}
+					render.Result(out, s.Title, r, render.Options{})
+				}
+				if emit != nil {
+					if err := emit.Close(); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if err := out.Flush(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"sort"
+)
+
+// usesDevice reports whether any process needs the secondary device.
+func usesDevice(processes []Process) bool {
+	for _, p := range processes {
+		if p.DeviceTime > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DualResourceSchedule schedules processes first-come, first-serve across
+// two resources: the CPU and a secondary device. A process that needs the
+// device is only dispatched once both are free; it then holds the CPU for
+// its burst and the device for its device time, so a long device hold can
+// keep the next device user waiting while CPU-only processes run. Device
+// users are served in arrival order among themselves, and when the earliest
+// waiting process is blocked on the device the CPU goes to the next process
+// that can run.
+func DualResourceSchedule(ctx context.Context, processes []Process, limits Limits) Result {
+	pending := copyProcesses(processes)
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].ArrivalTime < pending[j].ArrivalTime
+	})
+
+	var (
+		now         int64
+		deviceFree  int64
+		ready       []Process
+		rows        = make([]Row, 0, len(processes))
+		gantt       = make([]TimeSlice, 0)
+		deviceGantt = make([]TimeSlice, 0)
+	)
+	for len(pending) > 0 || len(ready) > 0 {
+		if halted(ctx, limits, now, len(gantt)) {
+			break
+		}
+		for len(pending) > 0 && pending[0].ArrivalTime <= now {
+			ready = append(ready, pending[0])
+			pending = pending[1:]
+		}
+
+		next := -1
+		for i, p := range ready {
+			if p.DeviceTime == 0 || deviceFree <= now {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// Nothing can run until the device frees up or another
+			// process arrives.
+			wake := deviceFree
+			if len(ready) == 0 || (len(pending) > 0 && pending[0].ArrivalTime < wake) {
+				wake = pending[0].ArrivalTime
+			}
+			now = wake
+			continue
+		}
+		process := ready[next]
+		ready = append(ready[:next], ready[next+1:]...)
+
+		start := now
+		burst, stop, killed := runFor(process, start, process.BurstDuration)
+		if burst == 0 {
+			// Killed while waiting; it never held either resource.
+			start = stop
+		} else {
+			now = stop
+			gantt = append(gantt, TimeSlice{
+				PID:   process.ProcessID,
+				Start: start,
+				Stop:  stop,
+			})
+			if process.DeviceTime > 0 {
+				deviceFree = start + process.DeviceTime
+				if killed && stop < deviceFree {
+					deviceFree = stop
+				}
+				deviceGantt = append(deviceGantt, TimeSlice{
+					PID:   process.ProcessID,
+					Start: start,
+					Stop:  deviceFree,
+				})
+			}
+		}
+
+		rows = append(rows, Row{
+			PID:        process.ProcessID,
+			Priority:   process.Priority,
+			Burst:      burst,
+			Arrival:    process.ArrivalTime,
+			Wait:       start - process.ArrivalTime,
+			Turnaround: stop - process.ArrivalTime,
+			Exit:       stop,
+			Killed:     killed,
+		})
+	}
+
+	return Result{Rows: rows, Gantt: gantt, DeviceGantt: deviceGantt, Stats: summarize(rows)}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// SchemaVersion is the version of the Envelope layout and everything in it.
+// Bump it whenever a field is renamed, removed or changes meaning; adding a
+// field doesn't need a bump.
+const SchemaVersion = 1
+
+// Envelope wraps one scheduler's Result in machine-readable output with what
+// a downstream tool needs to interpret it.
+type Envelope struct {
+	SchemaVersion int    `json:"schema_version"`
+	Algorithm     string `json:"algorithm"`
+	Params        Params `json:"params"`
+	Result        Result `json:"result"`
+	// Metrics holds the values of the metrics selected with -metrics.
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+}
+
+// Emitter writes envelopes in one machine-readable format.
+type Emitter interface {
+	Add(e Envelope)
+	// Close flushes anything buffered and reports the first write error.
+	Close() error
+}
+
+// EmitterFor returns an Emitter writing format to w: json (an indented array
+// of envelopes), ndjson (one envelope per line) or gob (a stream of
+// envelopes). The table format is rendered for people rather than emitted,
+// so it has no Emitter and nil is returned.
+func EmitterFor(format string, w io.Writer) (Emitter, error) {
+	switch format {
+	case "table":
+		return nil, nil
+	case "json":
+		return &jsonEmitter{w: w}, nil
+	case "ndjson":
+		return &streamEmitter{encode: json.NewEncoder(w).Encode}, nil
+	case "gob":
+		return &streamEmitter{encode: gob.NewEncoder(w).Encode}, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown output format %q", ErrInvalidArgs, format)
+}
+
+// finite replaces NaN and infinite stats and metrics in env with 0, which
+// JSON can't encode. They come from averaging over no rows, or from a
+// throughput over no elapsed time, as in a run truncated before anything
+// ran.
+func finite(env Envelope) Envelope {
+	fix := func(v float64) float64 {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0
+		}
+
+		return v
+	}
+	st := &env.Result.Stats
+	st.AveWait, st.AveTurnaround, st.Throughput = fix(st.AveWait), fix(st.AveTurnaround), fix(st.Throughput)
+	if env.Metrics != nil {
+		metrics := make(map[string]float64, len(env.Metrics))
+		for name, v := range env.Metrics {
+			metrics[name] = fix(v)
+		}
+		env.Metrics = metrics
+	}
+
+	return env
+}
+
+// jsonEmitter writes envelopes as the elements of one indented array, each
+// as soon as it is added, so a large run is never encoded all at once.
+type jsonEmitter struct {
+	w     io.Writer
+	count int
+	err   error
+}
+
+func (e *jsonEmitter) Add(env Envelope) {
+	if e.err != nil {
+		return
+	}
+	b, err := json.MarshalIndent(finite(env), "  ", "  ")
+	if err != nil {
+		e.err = err
+		return
+	}
+	sep := "[\n  "
+	if e.count > 0 {
+		sep = ",\n  "
+	}
+	e.count++
+	if _, e.err = io.WriteString(e.w, sep); e.err == nil {
+		_, e.err = e.w.Write(b)
+	}
+}
+
+func (e *jsonEmitter) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+
+	return err
+}
+
+// streamEmitter writes each envelope as soon as it is added.
+type streamEmitter struct {
+	encode func(v any) error
+	err    error
+}
+
+func (e *streamEmitter) Add(env Envelope) {
+	if e.err == nil {
+		e.err = e.encode(finite(env))
+	}
+}
+
+func (e *streamEmitter) Close() error {
+	return e.err
+}
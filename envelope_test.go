@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestEmitTruncatedRunAsJSON(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Priority: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 9, Priority: 5},
+	}
+	params := Params{MaxTime: 3, Seed: 1, CPUs: 1}
+
+	for _, format := range []string{"json", "ndjson"} {
+		var buf bytes.Buffer
+		emitter, err := EmitterFor(format, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range buildSchedulers(processes, params) {
+			r := s.Run(context.Background(), processes, params.Limits())
+			if r.Truncated == "" {
+				t.Errorf("%s: run cut at time 3 not marked truncated", s.Title)
+			}
+			emitter.Add(Envelope{SchemaVersion: SchemaVersion, Algorithm: s.Title, Params: params, Result: r})
+		}
+		// Averages over nothing are what broke encoding before.
+		emitter.Add(Envelope{
+			Algorithm: "empty",
+			Result:    Result{Stats: Stats{AveWait: math.NaN(), Throughput: math.Inf(1)}},
+			Metrics:   map[string]float64{"slowdown": math.NaN()},
+		})
+		if err := emitter.Close(); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		dec := json.NewDecoder(&buf)
+		for dec.More() {
+			var v any
+			if err := dec.Decode(&v); err != nil {
+				t.Fatalf("%s: emitted invalid JSON: %v", format, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/jonuorah26/CSCE4600-Project1
+
+go 1.21
+
+require (
+	github.com/olekukonko/tablewriter v0.0.5
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require github.com/mattn/go-runewidth v0.0.9 // indirect
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"strconv"
+)
+
+// GuaranteedSchedule implements guaranteed (fair-share) scheduling. With n
+// processes in the system each is entitled to 1/n of the CPU for as long as
+// it is there; every time unit the process with the lowest ratio of CPU time
+// consumed to CPU time entitled runs. Each process's final ratio is added to
+// the schedule table, where 1 means it got exactly its fair share.
+func GuaranteedSchedule(ctx context.Context, processes []Process, limits Limits) Result {
+	entitled := make(map[int64]float64, len(processes))
+	ratio := func(j *job) float64 {
+		if j.entitled == 0 {
+			return 0
+		}
+		return float64(j.BurstDuration-j.remaining) / j.entitled
+	}
+	lower := func(a, b *job) bool {
+		return ratio(a) < ratio(b)
+	}
+
+	r := preemptive(ctx, processes, preemptivePolicy{
+		better:   lower,
+		preempts: lower,
+		tick:     1,
+		elapsed: func(ready []*job, dt int64) {
+			share := float64(dt) / float64(len(ready))
+			for _, j := range ready {
+				j.entitled += share
+				// Recorded as it grows so that jobs still in the system
+				// when a run is cut short keep their ratio.
+				entitled[j.ProcessID] = j.entitled
+			}
+		},
+	}, limits)
+
+	consumed := make(map[int64]int64, len(processes))
+	last := make(map[int64]int, len(processes))
+	for i, row := range r.Rows {
+		consumed[row.PID] += row.Burst
+		last[row.PID] = i
+	}
+	r.ExtraColumns = []string{"Consumed/entitled"}
+	for pid, i := range last {
+		cell := "-"
+		if entitled[pid] > 0 {
+			cell = strconv.FormatFloat(float64(consumed[pid])/entitled[pid], 'f', 2, 64)
+		}
+		r.Rows[i].Extra = []string{cell}
+	}
+
+	return r
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGuaranteedSchedule(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+		want   map[int64]string
+	}{
+		{
+			name: "run to completion",
+			want: map[int64]string{1: "0.86", 2: "1.12", 3: "1.20"},
+		},
+		{
+			// Every process that ran keeps its ratio when the run is cut.
+			name:   "cut short",
+			limits: Limits{MaxTime: 6},
+			want:   map[int64]string{1: "1.06", 2: "1.09", 3: "0.75"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Scheduler{Schedule: GuaranteedSchedule}.Run(context.Background(), workload, tt.limits)
+			if !reflect.DeepEqual(r.ExtraColumns, []string{"Consumed/entitled"}) {
+				t.Fatalf("extra columns = %q", r.ExtraColumns)
+			}
+			got := make(map[int64]string)
+			for _, row := range r.Rows {
+				if len(row.Extra) > 0 {
+					got[row.PID] = row.Extra[0]
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ratios = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+)
+
+// Interactive steps every scheduler through processes together, reading
+// commands from in between steps:
+//
+//	run [n]               advance the clock by n time units (default 1); an
+//	                      empty line does the same
+//	<burst> [priority]    inject a new process arriving at the current time
+//	quit                  stop
+//
+// After each command it outputs every scheduler's Gantt chart up to the
+// current time. Injected processes only arrive from the current time
+// onwards, so re-running each simulation from the start gives the same
+// past as injecting them into a running one would.
+func Interactive(ctx context.Context, in io.Reader, w io.Writer, processes []Process, params Params, opts render.Options) error {
+	processes = copyProcesses(processes)
+	var (
+		clock   int64
+		nextPID int64
+	)
+	for _, p := range processes {
+		if p.ProcessID >= nextPID {
+			nextPID = p.ProcessID + 1
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, `Commands: "run [n]" (or an empty line) advances the clock, "<burst> [priority]" injects a process, "quit" stops`)
+	scanner := bufio.NewScanner(in)
+	for {
+		_, _ = fmt.Fprintf(w, "t=%d> ", clock)
+		if !scanner.Scan() {
+			_, _ = fmt.Fprintln(w)
+			break
+		}
+		fields := strings.Fields(strings.ReplaceAll(scanner.Text(), ",", " "))
+
+		switch {
+		case len(fields) > 0 && fields[0] == "quit":
+			return nil
+		case len(fields) == 0 || fields[0] == "run":
+			step := int64(1)
+			if len(fields) == 2 {
+				n, err := strconv.ParseInt(fields[1], 10, 64)
+				if err != nil || n <= 0 {
+					_, _ = fmt.Fprintf(w, "run takes a positive number of time units, not %q\n", fields[1])
+					continue
+				}
+				step = n
+			}
+			clock += step
+		default:
+			p, err := parseInjected(fields, nextPID, clock)
+			if err != nil {
+				_, _ = fmt.Fprintln(w, err)
+				continue
+			}
+			processes = append(processes, p)
+			nextPID++
+			_, _ = fmt.Fprintf(w, "Injected process %d (burst %d, priority %d) at time %d\n", p.ProcessID, p.BurstDuration, p.Priority, clock)
+		}
+		if clock == 0 {
+			continue
+		}
+
+		// Replay up to the clock, keeping any other limits given.
+		limits := params.Limits()
+		if limits.MaxTime == 0 || clock < limits.MaxTime {
+			limits.MaxTime = clock
+		}
+		for _, s := range buildSchedulers(processes, params) {
+			r := s.Run(ctx, processes, limits)
+			render.Title(w, s.Title)
+			render.Gantt(w, "Gantt schedule", r.Gantt, opts)
+			_, _ = fmt.Fprintf(w, "So far: average wait %.2f, average turnaround %.2f\n\n", r.Stats.AveWait, r.Stats.AveTurnaround)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseInjected builds the process described by an interactive command of a
+// burst and an optional priority.
+func parseInjected(fields []string, pid, now int64) (Process, error) {
+	if len(fields) > 2 {
+		return Process{}, fmt.Errorf("%w: expected a burst and an optional priority", ErrInvalidArgs)
+	}
+	p := Process{ProcessID: pid, ArrivalTime: now}
+	var err error
+	if p.BurstDuration, err = strconv.ParseInt(fields[0], 10, 64); err != nil || p.BurstDuration <= 0 {
+		return Process{}, fmt.Errorf("%w: burst must be a positive integer, not %q", ErrInvalidArgs, fields[0])
+	}
+	if len(fields) == 2 {
+		if p.Priority, err = strconv.ParseInt(fields[1], 10, 64); err != nil {
+			return Process{}, fmt.Errorf("%w: priority must be an integer, not %q", ErrInvalidArgs, fields[1])
+		}
+	}
+
+	return p, nil
+}
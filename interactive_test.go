@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+)
+
+func TestInteractive(t *testing.T) {
+	tests := []struct {
+		name      string
+		processes []Process
+		params    Params
+		input     string
+		want      string
+		unwanted  string
+	}{
+		{
+			name:   "empty workload",
+			params: Params{Seed: 1, CPUs: 1, Placement: "jsq", Tick: 1, Threshold: 1, Aging: 1, AdaptiveRR: true},
+			input:  "\n2\nquit\n",
+			want:   "Injected process 0 (burst 2, priority 0) at time 1",
+		},
+		{
+			// The replay stops at -max-time however far the clock runs.
+			name:      "max time",
+			processes: []Process{{ProcessID: 1, BurstDuration: 20}},
+			params:    Params{Seed: 1, CPUs: 1, Placement: "jsq", MaxTime: 5},
+			input:     "run 20\n",
+			want:      "0\t5\n",
+			unwanted:  "\t20",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := Interactive(context.Background(), strings.NewReader(tt.input), &out, tt.processes, tt.params, render.Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output doesn't contain %q:\n%s", tt.want, out.String())
+			}
+			if tt.unwanted != "" && strings.Contains(out.String(), tt.unwanted) {
+				t.Errorf("output contains %q:\n%s", tt.unwanted, out.String())
+			}
+		})
+	}
+}
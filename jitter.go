@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+	"github.com/olekukonko/tablewriter"
+)
+
+// JitterReport re-runs every algorithm runs times against copies of processes
+// whose arrival times are each shifted by a random amount in [-jitter, jitter],
+// then outputs how far each algorithm's metrics move from its baseline Stats.
+// The same seed always produces the same perturbations. If ctx is cancelled
+// the report covers the runs completed so far.
+func JitterReport(ctx context.Context, w io.Writer, algos []Scheduler, processes []Process, limits Limits, baseline []Stats, jitter int64, runs int, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+
+	var completed int
+	samples := make([][]Stats, len(algos))
+	for ; completed < runs; completed++ {
+		jittered := jitterArrivals(rng, processes, jitter)
+		stats := make([]Stats, len(algos))
+		for i, s := range algos {
+			stats[i] = s.Run(ctx, jittered, limits).Stats
+		}
+		// Leave out a run that was cut short by cancellation.
+		if ctx.Err() != nil {
+			break
+		}
+		for i := range stats {
+			samples[i] = append(samples[i], stats[i])
+		}
+	}
+	if completed == 0 {
+		return
+	}
+
+	rows := make([][]string, len(algos))
+	for i, s := range algos {
+		waits := make([]float64, len(samples[i]))
+		turnarounds := make([]float64, len(samples[i]))
+		for j := range samples[i] {
+			waits[j] = samples[i][j].AveWait
+			turnarounds[j] = samples[i][j].AveTurnaround
+		}
+		rows[i] = []string{
+			s.Title,
+			fmt.Sprintf("%.2f", baseline[i].AveWait),
+			fmt.Sprintf("%.2f ± %.2f", mean(waits), stddev(waits)),
+			fmt.Sprintf("%.2f", maxDeviation(waits, baseline[i].AveWait)),
+			fmt.Sprintf("%.2f", baseline[i].AveTurnaround),
+			fmt.Sprintf("%.2f ± %.2f", mean(turnarounds), stddev(turnarounds)),
+			fmt.Sprintf("%.2f", maxDeviation(turnarounds, baseline[i].AveTurnaround)),
+		}
+	}
+
+	render.Title(w, "Arrival-time sensitivity")
+	_, _ = fmt.Fprintf(w, "%d runs, arrivals jittered by up to ±%d (seed %d)\n", completed, jitter, seed)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Wait", "Jittered wait", "Max Δ", "Turnaround", "Jittered turnaround", "Max Δ"})
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// jitterArrivals returns a copy of processes with every arrival time moved by
+// a random amount in [-jitter, jitter], clamped at zero, and re-sorted into
+// arrival order.
+func jitterArrivals(rng *rand.Rand, processes []Process, jitter int64) []Process {
+	jittered := copyProcesses(processes)
+	for i := range jittered {
+		jittered[i].ArrivalTime += rng.Int63n(2*jitter+1) - jitter
+		if jittered[i].ArrivalTime < 0 {
+			jittered[i].ArrivalTime = 0
+		}
+	}
+	sort.SliceStable(jittered, func(i, j int) bool {
+		return jittered[i].ArrivalTime < jittered[j].ArrivalTime
+	})
+
+	return jittered
+}
+
+func mean(xs []float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+
+	return sum / float64(len(xs))
+}
+
+func stddev(xs []float64) float64 {
+	m := mean(xs)
+	var sum float64
+	for _, x := range xs {
+		sum += (x - m) * (x - m)
+	}
+
+	return math.Sqrt(sum / float64(len(xs)))
+}
+
+func maxDeviation(xs []float64, from float64) float64 {
+	var largest float64
+	for _, x := range xs {
+		largest = math.Max(largest, math.Abs(x-from))
+	}
+
+	return largest
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// Limits bounds how far a simulation may run so that one which never
+// finishes still stops with partial results. Zero fields are unlimited.
+type Limits struct {
+	// MaxTime is the simulated time at which scheduling stops.
+	MaxTime int64
+	// MaxEvents is the number of dispatches after which scheduling stops.
+	MaxEvents int
+}
+
+// Reached reports whether a simulation at time now that has dispatched
+// events slices must stop.
+func (l Limits) Reached(now int64, events int) bool {
+	return (l.MaxTime > 0 && now >= l.MaxTime) || (l.MaxEvents > 0 && events >= l.MaxEvents)
+}
+
+// halted reports whether a simulation at time now that has dispatched events
+// slices must stop, either because it reached limits or because ctx was
+// cancelled.
+func halted(ctx context.Context, limits Limits, now int64, events int) bool {
+	return limits.Reached(now, events) || ctx.Err() != nil
+}
+
+// Truncate cuts r, a schedule of processes, off at the limits. Slices still
+// running at the horizon are shortened to end there and anything after it is
+// dropped. Truncated is set, and the Stats recomputed from what is left, only
+// if work was actually lost: something was cut, or the scheduler stopped at a
+// limit before some process that wasn't killed got all of its burst. A run
+// that finishes exactly at the limit is left alone.
+func (l Limits) Truncate(r Result, processes []Process) Result {
+	horizon := int64(math.MaxInt64)
+	if l.MaxTime > 0 {
+		horizon = l.MaxTime
+	}
+	reason := fmt.Sprintf("stopped at time %d (-max-time)", l.MaxTime)
+	if l.MaxEvents > 0 && len(r.Gantt) >= l.MaxEvents && r.Gantt[l.MaxEvents-1].Stop <= horizon {
+		horizon = r.Gantt[l.MaxEvents-1].Stop
+		reason = fmt.Sprintf("stopped after %d dispatches (-max-events)", l.MaxEvents)
+	}
+	if horizon == math.MaxInt64 {
+		return r
+	}
+
+	cut := false
+	rows := make([]Row, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		if row.Start() >= horizon && (row.Burst > 0 || row.Exit > horizon) {
+			cut = true
+			continue
+		}
+		if row.Exit > horizon {
+			row.Burst -= row.Exit - horizon
+			row.Exit = horizon
+			row.Turnaround = horizon - row.Arrival
+			row.Killed = false
+			cut = true
+		}
+		rows = append(rows, row)
+	}
+	var end int64
+	if len(r.Gantt) > 0 {
+		end = r.Gantt[len(r.Gantt)-1].Stop
+	}
+	stopped := l.Reached(end, len(r.Gantt)) && unfinished(rows, processes, 0) ||
+		l.MaxTime > 0 && unfinished(rows, processes, l.MaxTime)
+	if !cut && !stopped {
+		return r
+	}
+
+	r.Gantt, r.Rows, r.Stats = cutGantt(r.Gantt, horizon), rows, summarize(rows)
+	r.Truncated = reason
+	if r.DeviceGantt != nil {
+		r.DeviceGantt = cutGantt(r.DeviceGantt, horizon)
+	}
+	for c := range r.CPUGantts {
+		r.CPUGantts[c] = cutGantt(r.CPUGantts[c], horizon)
+	}
+	trace := r.Trace[:0]
+	for _, e := range r.Trace {
+		if e.Time <= horizon {
+			trace = append(trace, e)
+		}
+	}
+	r.Trace = trace
+
+	return r
+}
+
+// unfinished reports whether any of processes arriving at or after from that
+// wasn't killed received less CPU time in rows than its burst.
+func unfinished(rows []Row, processes []Process, from int64) bool {
+	consumed := make(map[int64]int64, len(processes))
+	killed := make(map[int64]bool)
+	for _, row := range rows {
+		consumed[row.PID] += row.Burst
+		if row.Killed {
+			killed[row.PID] = true
+		}
+	}
+	for _, p := range processes {
+		if p.ArrivalTime >= from && !killed[p.ProcessID] && consumed[p.ProcessID] < p.BurstDuration {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cutGantt returns the slices of gantt that start before horizon, shortening
+// any still running at it.
+func cutGantt(gantt []TimeSlice, horizon int64) []TimeSlice {
+	cut := make([]TimeSlice, 0, len(gantt))
+	for _, g := range gantt {
+		if g.Start >= horizon {
+			continue
+		}
+		if g.Stop > horizon {
+			g.Stop = horizon
+		}
+		cut = append(cut, g)
+	}
+
+	return cut
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+	}
+	first := Row{PID: 1, Burst: 4, Arrival: 0, Wait: 0, Turnaround: 4, Exit: 4}
+	second := Row{PID: 2, Burst: 4, Arrival: 1, Wait: 3, Turnaround: 7, Exit: 8}
+	full := Result{
+		Rows:  []Row{first, second},
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 8}},
+		Stats: summarize([]Row{first, second}),
+	}
+	// What a scheduler that stopped itself at time 4 returns.
+	stopped := Result{
+		Rows:  []Row{first},
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}},
+		Stats: summarize([]Row{first}),
+	}
+	cutSecond := Row{PID: 2, Burst: 2, Arrival: 1, Wait: 3, Turnaround: 5, Exit: 6}
+
+	tests := []struct {
+		name   string
+		limits Limits
+		in     Result
+		want   Result
+	}{
+		{
+			name: "no limits",
+			in:   full,
+			want: full,
+		},
+		{
+			name:   "slice cut at max time",
+			limits: Limits{MaxTime: 6},
+			in:     full,
+			want: Result{
+				Rows:      []Row{first, cutSecond},
+				Gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}},
+				Stats:     summarize([]Row{first, cutSecond}),
+				Truncated: "stopped at time 6 (-max-time)",
+			},
+		},
+		{
+			name:   "finished exactly at max time",
+			limits: Limits{MaxTime: 8},
+			in:     full,
+			want:   full,
+		},
+		{
+			name:   "max events",
+			limits: Limits{MaxEvents: 1},
+			in:     full,
+			want: Result{
+				Rows:      []Row{first},
+				Gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 4}},
+				Stats:     summarize([]Row{first}),
+				Truncated: "stopped after 1 dispatches (-max-events)",
+			},
+		},
+		{
+			name:   "scheduler stopped at the limit",
+			limits: Limits{MaxTime: 4},
+			in:     stopped,
+			want: Result{
+				Rows:      []Row{first},
+				Gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 4}},
+				Stats:     summarize([]Row{first}),
+				Truncated: "stopped at time 4 (-max-time)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.limits.Truncate(tt.in, processes)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,41 +1,319 @@
 package main
 
 import (
-	"encoding/csv"
-	"errors"
+	"bufio"
+	"context"
+	"flag"
 	"fmt"
-	"io"
 	"log"
 	"os"
+	"os/signal"
 	"sort"
 	"strconv"
-	"strings"
 
-	"github.com/olekukonko/tablewriter"
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+	"github.com/jonuorah26/CSCE4600-Project1/sim"
 )
 
+// Scheduler pairs a scheduling algorithm with the title it is reported under.
+type Scheduler struct {
+	Title    string
+	Schedule func(ctx context.Context, processes []Process, limits Limits) Result
+}
+
+// Run schedules a copy of processes, leaving the caller's slice untouched,
+// and cuts the result off at limits. If ctx is cancelled first, the partial
+// result so far is returned marked as truncated.
+func (s Scheduler) Run(ctx context.Context, processes []Process, limits Limits) Result {
+	r := limits.Truncate(s.Schedule(ctx, copyProcesses(processes), limits), processes)
+	if err := ctx.Err(); err != nil {
+		r.Truncated = fmt.Sprintf("cancelled (%v)", err)
+		r.Stats = summarize(r.Rows)
+	}
+
+	return r
+}
+
+// schedulers lists every scheduling algorithm in the order they are run.
+var schedulers = []Scheduler{
+	{"First-come, first-serve", FCFSSchedule},
+	{"Shortest-job-first", SJFSchedule},
+	{"Shortest-remaining-time-first", SRTFSchedule},
+	{"Priority", SJFPrioritySchedule},
+	{"Round-robin", RRSchedule},
+}
+
+// Params holds the settings that change what the schedulers compute. They
+// come from the command-line flags or a scenario's params section.
+type Params struct {
+	Seed      int64 `json:"seed" yaml:"seed"`
+	Tick      int64 `json:"tick" yaml:"tick"`
+	Aging     int64 `json:"aging" yaml:"aging"`
+	Threshold int64 `json:"threshold" yaml:"threshold"`
+	MaxTime   int64 `json:"max_time" yaml:"max_time"`
+	MaxEvents int   `json:"max_events" yaml:"max_events"`
+	// CPUs above one partitions processes across that many CPUs, each
+	// placed by Placement (see placements).
+	CPUs      int    `json:"cpus" yaml:"cpus"`
+	Placement string `json:"placement" yaml:"placement"`
+	// Workload transforms applied after loading; see transformWorkload.
+	ScaleBurst   float64 `json:"scale_burst" yaml:"scale_burst"`
+	ScaleArrival float64 `json:"scale_arrival" yaml:"scale_arrival"`
+	ShiftArrival int64   `json:"shift_arrival" yaml:"shift_arrival"`
+	// AdaptiveRR adds round-robin with a per-cycle median quantum.
+	AdaptiveRR bool `json:"adaptive_rr" yaml:"adaptive_rr"`
+	// Guaranteed adds guaranteed scheduling, which re-ranks every waiting
+	// process each time unit and so is left out unless asked for.
+	Guaranteed bool `json:"guaranteed" yaml:"guaranteed"`
+	// Window, when positive, is the size of the windows completions are
+	// counted in for the throughput series.
+	Window int64 `json:"window" yaml:"window"`
+}
+
+// Limits returns the simulation limits set in p.
+func (p Params) Limits() Limits {
+	return Limits{MaxTime: p.MaxTime, MaxEvents: p.MaxEvents}
+}
+
+// buildSchedulers returns the schedulers followed by those that take
+// parameters or only suit some workloads: the seeded random scheduler,
+// tick-driven SRTF, threshold-preemptive priority, priority with aging,
+// adaptive round-robin and guaranteed scheduling when their parameters are
+// set, and the dual-resource scheduler when any of processes needs the secondary
+// device. With more than one CPU every scheduler becomes a per-CPU policy of
+// a partitioned run.
+func buildSchedulers(processes []Process, params Params) []Scheduler {
+	algos := append([]Scheduler(nil), schedulers...)
+	algos = append(algos, Scheduler{fmt.Sprintf("Random (seed %d)", params.Seed), RandomSchedule(params.Seed)})
+	if params.Tick > 0 {
+		algos = append(algos, Scheduler{
+			Title:    fmt.Sprintf("Shortest-remaining-time-first (tick %d)", params.Tick),
+			Schedule: TickSRTFSchedule(params.Tick),
+		})
+	}
+	if params.Threshold > 0 {
+		algos = append(algos, Scheduler{
+			Title:    fmt.Sprintf("Preemptive priority (threshold %d)", params.Threshold),
+			Schedule: ThresholdPrioritySchedule(params.Threshold),
+		})
+	}
+	if params.Aging > 0 {
+		algos = append(algos, Scheduler{
+			Title:    fmt.Sprintf("Priority with aging (every %d)", params.Aging),
+			Schedule: AgingPrioritySchedule(params.Aging),
+		})
+	}
+	if params.AdaptiveRR {
+		algos = append(algos, Scheduler{"Adaptive round-robin", AdaptiveRRSchedule})
+	}
+	if params.Guaranteed {
+		algos = append(algos, Scheduler{"Guaranteed", GuaranteedSchedule})
+	}
+	if usesDevice(processes) {
+		algos = append(algos, Scheduler{"Dual-resource first-come, first-serve", DualResourceSchedule})
+	}
+	if params.CPUs > 1 {
+		for i, s := range algos {
+			algos[i] = Scheduler{
+				Title:    fmt.Sprintf("%s on %d CPUs (%s placement)", s.Title, params.CPUs, params.Placement),
+				Schedule: PartitionedSchedule(params.CPUs, params.Placement, params.Seed, s.Schedule),
+			}
+		}
+	}
+
+	return algos
+}
+
 func main() {
+	// CLI flags
+	var (
+		params Params
+		opts   render.Options
+	)
+	jitter := flag.Int64("jitter", 0, "perturb arrival times by up to ±`n` and report how sensitive each algorithm is")
+	jitterRuns := flag.Int("jitter-runs", 20, "number of jittered runs per algorithm")
+	flag.Int64Var(&params.Seed, "seed", 1, "seed for the random scheduler and jittered arrivals")
+	format := flag.String("format", "", "input `format` (csv, json, yaml, perf); defaults to the file extension")
+	timeout := flag.Duration("timeout", 0, "give up on simulations still running after `duration` and report partial results")
+	output := flag.String("output", "table", "output `format`: table, or json, ndjson or gob for tools")
+	sortBy := flag.String("sort", "", "order schedule table rows by `key`: pid, completion or dispatch")
+	flag.Int64Var(&params.MaxTime, "max-time", 0, "stop every simulation at time `t` and report partial results")
+	flag.IntVar(&params.MaxEvents, "max-events", 0, "stop every simulation after `n` dispatches and report partial results")
+	flag.Int64Var(&params.Tick, "tick", 0, "also run SRTF re-evaluating preemption only every `n` time units and compare it to ideal SRTF")
+	flag.Int64Var(&params.Threshold, "threshold", 0, "also run preemptive priority where a process only preempts if its priority is better by more than `n`")
+	flag.Int64Var(&params.Aging, "aging", 0, "also run priority scheduling where waiting processes gain a level every `n` time units")
+	flag.Int64Var(&params.Window, "window", 0, "also report completions per window of `n` time units")
+	flag.BoolVar(&params.AdaptiveRR, "adaptive-rr", false, "also run round-robin with the median remaining burst as each cycle's quantum and compare it to fixed-quantum RR")
+	flag.BoolVar(&params.Guaranteed, "guaranteed", false, "also run guaranteed scheduling, which is slow on large traces")
+	flag.IntVar(&params.CPUs, "cpus", 1, "partition processes across `n` CPUs, each running the scheduler on its own")
+	flag.StringVar(&params.Placement, "placement", "jsq", "how arrivals are placed on CPUs: jsq, jiq or rr")
+	flag.Float64Var(&params.ScaleBurst, "scale-burst", 0, "multiply every burst by `factor` after loading")
+	flag.Float64Var(&params.ScaleArrival, "scale-arrival", 0, "multiply every arrival time by `factor` after loading")
+	flag.Int64Var(&params.ShiftArrival, "shift-arrival", 0, "move every arrival time by `n` after loading, stopping at 0")
+	horizon := flag.Int64("horizon", 0, "reject processes arriving after time `t`")
+	fix := flag.Bool("fix", false, "repair invalid input instead of rejecting it: renumber duplicate IDs and clamp bad values")
+	metricNames := flag.String("metrics", "", "report the comma-separated `names` metrics for each algorithm (wait, turnaround, throughput, switches, makespan, utilization, max-wait)")
+	byPriority := flag.Bool("by-priority", false, "report each algorithm's average wait and turnaround per priority level")
+	interactive := flag.Bool("interactive", false, "step through the schedules, injecting processes typed on stdin")
+	flag.BoolVar(&opts.Trace, "trace", false, "print the event trace of schedulers that record one")
+	flag.BoolVar(&opts.Annotate, "gantt-priority", false, "annotate Gantt cells with the effective priority, where known")
+	ruler := flag.String("ruler", "", "draw Gantt charts to scale over a time axis with ticks every `major[,minor]` time units")
+	flag.Int64Var(&opts.Resolution, "resolution", 1, "time units per character of to-scale Gantt charts")
+	flag.Parse()
+	if err := render.CheckSortKey(*sortBy); err != nil {
+		log.Fatal(err)
+	}
+	if err := checkPlacement(params.Placement); err != nil {
+		log.Fatal(err)
+	}
+	// Results can run to millions of lines, so write them through a buffer
+	out := bufio.NewWriter(os.Stdout)
+	defer func() {
+		if err := out.Flush(); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	emit, err := EmitterFor(*output, out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var selected []string
+	if *metricNames != "" {
+		if selected, err = SelectMetrics(*metricNames); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *ruler != "" {
+		if opts.RulerMajor, opts.RulerMinor, err = render.ParseRuler(*ruler); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Interrupting or timing out a long run stops it with partial results
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	// Check mode: run a scenario bundle against its expected results
+	if flag.Arg(0) == "check" {
+		if flag.NArg() != 2 {
+			log.Fatalf("%v: must give a scenario file to check", ErrInvalidArgs)
+		}
+		passed, err := CheckScenario(ctx, os.Stdout, flag.Arg(1))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !passed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Analysis mode: bound periodic tasks' response times and simulate them
+	if flag.Arg(0) == "rta" {
+		if flag.NArg() != 2 {
+			log.Fatalf("%v: must give a task file to analyse", ErrInvalidArgs)
+		}
+		f, closeFile, err := openProcessingFile(os.Args[0], flag.Arg(1))
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer closeFile()
+		tasks, err := loadTasks(f)
+		if err != nil {
+			log.Fatal(err)
+		}
+		RTAReport(ctx, os.Stdout, tasks, params.Limits())
+		return
+	}
+
 	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
+	f, closeFile, err := openProcessingFile(append([]string{os.Args[0]}, flag.Args()...)...)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer closeFile()
 
 	// Load and parse processes
-	processes, err := loadProcesses(f)
+	loader, err := sim.LoaderFor(*format, f.Name())
 	if err != nil {
 		log.Fatal(err)
 	}
+	processes, err := loader.Load(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+	processes, fixes, err := validateWorkload(processes, *horizon, *fix)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, fixed := range fixes {
+		log.Printf("fixed %s", fixed)
+	}
+	if processes, err = transformWorkload(processes, params); err != nil {
+		log.Fatal(err)
+	}
 
-	// First-come, first-serve scheduling
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
+	if *interactive {
+		if err := Interactive(ctx, os.Stdin, os.Stdout, processes, params, opts); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	algos := buildSchedulers(processes, params)
+	limits := params.Limits()
+	baseline := make([]Stats, len(algos))
+	results := make([]Result, len(algos))
+	titles := make([]string, len(algos))
+	for i, s := range algos {
+		result := s.Run(ctx, processes, limits)
+		render.SortRows(result.Rows, *sortBy)
+		if params.Window > 0 {
+			result.Series = throughputSeries(result.Rows, processes, params.Window)
+		}
+		baseline[i] = result.Stats
+		results[i], titles[i] = result, s.Title
+		if emit != nil {
+			emit.Add(Envelope{
+				SchemaVersion: SchemaVersion,
+				Algorithm:     s.Title,
+				Params:        params,
+				Result:        result,
+				Metrics:       computeMetrics(selected, result),
+			})
+			continue
+		}
+		render.Result(out, s.Title, result, opts)
+	}
 
-	SJFSchedule(os.Stdout, "Shortest-job-first", processes)
-	//
-	SJFPrioritySchedule(os.Stdout, "Priority", processes)
-	//
-	RRSchedule(os.Stdout, "Round-robin", processes)
+	// Machine-readable output carries only the envelopes
+	if emit != nil {
+		if err := emit.Close(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if len(selected) > 0 {
+		MetricsReport(out, selected, titles, results)
+	}
+	if *byPriority {
+		PriorityReport(out, titles, results)
+	}
+	if params.Tick > 0 {
+		TickReport(ctx, out, processes, params.Tick, limits)
+	}
+	if params.AdaptiveRR {
+		AdaptiveRRReport(ctx, out, processes, limits)
+	}
+	if *jitter > 0 {
+		JitterReport(ctx, out, algos, processes, limits, baseline, *jitter, *jitterRuns, params.Seed)
+	}
 }
 
 func openProcessingFile(args ...string) (*os.File, func(), error) {
@@ -57,92 +335,128 @@ func openProcessingFile(args ...string) (*os.File, func(), error) {
 }
 
 type (
-	Process struct {
-		ProcessID     int64
-		ArrivalTime   int64
-		BurstDuration int64
-		Priority      int64
+	// Process is one process of a workload.
+	Process = sim.Process
+	// The parts of a schedule, as returned by every scheduler.
+	TimeSlice  = sim.TimeSlice
+	Row        = sim.Row
+	Stats      = sim.Stats
+	Result     = sim.Result
+	TraceEvent = sim.TraceEvent
+	Window     = sim.Window
+)
+
+// copyProcesses returns a copy of processes so a scheduler may reorder or
+// modify it without affecting the next scheduler.
+func copyProcesses(processes []Process) []Process {
+	return append([]Process(nil), processes...)
+}
+
+// runFor dispatches p at start for up to want time units, cutting the run
+// short at p's kill time. It returns how long p actually ran, the time it
+// left the CPU and whether it was killed.
+func runFor(p Process, start, want int64) (burst, stop int64, killed bool) {
+	if p.KillTime <= 0 || start+want <= p.KillTime {
+		return want, start + want, false
 	}
-	TimeSlice struct {
-		PID   int64
-		Start int64
-		Stop  int64
+	if start >= p.KillTime {
+		// Killed before it was dispatched.
+		return 0, p.KillTime, true
 	}
-)
+
+	return p.KillTime - start, p.KillTime, true
+}
 
 //region Schedulers
 
-// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
-// • an output writer
-// • a title for the chart
-// • a slice of processes
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
+// FCFSSchedule schedules processes first-come, first-serve and returns the
+// per-process rows, Gantt slices and aggregate Stats of the schedule.
+func FCFSSchedule(ctx context.Context, processes []Process, limits Limits) Result {
 	var (
 		serviceTime     int64
 		totalWait       float64
 		totalTurnaround float64
 		lastCompletion  float64
 		waitingTime     int64
-		schedule        = make([][]string, len(processes))
+		rows            = make([]Row, 0, len(processes))
 		gantt           = make([]TimeSlice, 0)
 	)
 	for i := range processes {
+		if halted(ctx, limits, serviceTime, len(gantt)) {
+			break
+		}
 		if processes[i].ArrivalTime > 0 {
 			waitingTime = serviceTime - processes[i].ArrivalTime
 		}
-		totalWait += float64(waitingTime)
-
 		start := waitingTime + processes[i].ArrivalTime
 
-		turnaround := processes[i].BurstDuration + waitingTime
+		burst, _, killed := runFor(processes[i], start, processes[i].BurstDuration)
+		wait := waitingTime
+		if killed && burst == 0 {
+			// Killed while still waiting in the queue.
+			wait = processes[i].KillTime - processes[i].ArrivalTime
+		}
+		totalWait += float64(wait)
+
+		turnaround := burst + wait
 		totalTurnaround += float64(turnaround)
 
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
+		completion := burst + processes[i].ArrivalTime + wait
 		lastCompletion = float64(completion)
 
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
-		serviceTime += processes[i].BurstDuration
-
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
+		rows = append(rows, Row{
+			PID:        processes[i].ProcessID,
+			Priority:   processes[i].Priority,
+			Burst:      burst,
+			Arrival:    processes[i].ArrivalTime,
+			Wait:       wait,
+			Turnaround: turnaround,
+			Exit:       completion,
+			Killed:     killed,
 		})
+		serviceTime += burst
+
+		if burst > 0 {
+			gantt = append(gantt, TimeSlice{
+				PID:   processes[i].ProcessID,
+				Start: start,
+				Stop:  serviceTime,
+			})
+		}
 	}
 
+	if len(rows) == 0 {
+		// Stopped before anything ran; leave the stats at zero.
+		return Result{Rows: rows, Gantt: gantt}
+	}
 	count := float64(len(processes))
 	aveWait := totalWait / count
 	aveTurnaround := totalTurnaround / count
 	aveThroughput := count / lastCompletion
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return Result{
+		Rows:  rows,
+		Gantt: gantt,
+		Stats: Stats{AveWait: aveWait, AveTurnaround: aveTurnaround, Throughput: aveThroughput},
+	}
 }
 
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
+func SJFPrioritySchedule(ctx context.Context, processes []Process, limits Limits) Result {
 	var (
 		serviceTime     int64
 		totalWait       float64
 		totalTurnaround float64
 		lastCompletion  float64
 		waitingTime     int64
-		schedule        = make([][]string, 100)
+		rows            = make([]Row, 0, len(processes))
 		gantt           = make([]TimeSlice, 0)
 	)
+	if len(processes) == 0 {
+		return Result{Rows: rows, Gantt: gantt}
+	}
 
-	ct := 0
-
-	aTimesMap := make(map[int]Process)
-	processesLeft := make(map[int]Process)
+	aTimesMap := make(map[int]Process, len(processes))
+	processesLeft := make(map[int]Process, len(processes))
 	aTimeNums := make([]int, 0, len(processes))
 	for i := range processes {
 		aTimesMap[int(processes[i].ArrivalTime)] = processes[i]
@@ -168,78 +482,108 @@ func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
 		if i == 0 {
 			continue
 		}
+		if halted(ctx, limits, serviceTime, len(gantt)) {
+			// serviceTime has already moved on to this arrival, so emit the
+			// process still running since start for the partial result.
+			burst, completion, killed := runFor(currProcess, start, currProcess.BurstDuration)
+			rows = append(rows, Row{
+				PID:        currProcess.ProcessID,
+				Priority:   currProcess.Priority,
+				Burst:      burst,
+				Arrival:    currProcess.ArrivalTime,
+				Wait:       start,
+				Turnaround: completion - currProcess.ArrivalTime,
+				Exit:       completion,
+				Killed:     killed,
+			})
+			if burst > 0 {
+				gantt = append(gantt, TimeSlice{
+					PID:   currProcess.ProcessID,
+					Start: start,
+					Stop:  start + burst,
+				})
+			}
+			delete(processesLeft, int(currProcess.ProcessID))
+			break
+		}
 
 		if aTimesMap[a].Priority < currProcess.Priority {
 			waitingTime = start
 			totalWait += float64(waitingTime)
 
-			completion := a
+			burst, completion, killed := runFor(currProcess, start, int64(a)-start)
 			lastCompletion = float64(completion)
 
-			turnaround := completion - int(currProcess.ArrivalTime)
+			turnaround := completion - currProcess.ArrivalTime
 			totalTurnaround += float64(turnaround)
 
-			schedule[ct] = []string{
-				fmt.Sprint(currProcess.ProcessID),
-				fmt.Sprint(currProcess.Priority),
-				fmt.Sprint(completion - int(start)),
-				fmt.Sprint(currProcess.ArrivalTime),
-				fmt.Sprint(waitingTime),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
-			}
-
-			gantt = append(gantt, TimeSlice{
-				PID:   currProcess.ProcessID,
-				Start: start,
-				Stop:  serviceTime,
+			rows = append(rows, Row{
+				PID:        currProcess.ProcessID,
+				Priority:   currProcess.Priority,
+				Burst:      burst,
+				Arrival:    currProcess.ArrivalTime,
+				Wait:       waitingTime,
+				Turnaround: turnaround,
+				Exit:       completion,
+				Killed:     killed,
 			})
 
-			currProcess.BurstDuration -= int64(a)
-			processesLeft[int(currProcess.ProcessID)] = currProcess
+			if burst > 0 {
+				gantt = append(gantt, TimeSlice{
+					PID:   currProcess.ProcessID,
+					Start: start,
+					Stop:  start + burst,
+				})
+			}
+
+			if killed {
+				delete(processesLeft, int(currProcess.ProcessID))
+			} else {
+				currProcess.BurstDuration -= int64(a)
+				processesLeft[int(currProcess.ProcessID)] = currProcess
+			}
 
 			currProcess = aTimesMap[a]
 
 			start = int64(a)
-
-			ct += 1
 		}
 
 		if int(currProcess.ArrivalTime+currProcess.BurstDuration) <= a || i == len(aTimeNums)-1 {
 			waitingTime = start
 			totalWait += float64(waitingTime)
 
-			completion := start + currProcess.BurstDuration
+			burst, completion, killed := runFor(currProcess, start, currProcess.BurstDuration)
 			lastCompletion = float64(completion)
 
 			turnaround := completion - currProcess.ArrivalTime
 			totalTurnaround += float64(turnaround)
 
-			schedule[ct] = []string{
-				fmt.Sprint(currProcess.ProcessID),
-				fmt.Sprint(currProcess.Priority),
-				fmt.Sprint(completion - start),
-				fmt.Sprint(currProcess.ArrivalTime),
-				fmt.Sprint(waitingTime),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
-			}
+			rows = append(rows, Row{
+				PID:        currProcess.ProcessID,
+				Priority:   currProcess.Priority,
+				Burst:      burst,
+				Arrival:    currProcess.ArrivalTime,
+				Wait:       waitingTime,
+				Turnaround: turnaround,
+				Exit:       completion,
+				Killed:     killed,
+			})
 
-			serviceTime = completion
+			serviceTime = start + burst
 
-			gantt = append(gantt, TimeSlice{
-				PID:   currProcess.ProcessID,
-				Start: start,
-				Stop:  serviceTime,
-			})
+			if burst > 0 {
+				gantt = append(gantt, TimeSlice{
+					PID:   currProcess.ProcessID,
+					Start: start,
+					Stop:  serviceTime,
+				})
+			}
 
 			delete(processesLeft, int(currProcess.ProcessID))
 
 			currProcess = aTimesMap[a]
 
 			start = int64(a)
-
-			ct += 1
 		}
 	}
 
@@ -262,6 +606,9 @@ func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
 
 	sort.Ints(prioritys)
 	for _, p := range prioritys {
+		if halted(ctx, limits, serviceTime, len(gantt)) {
+			break
+		}
 
 		if len(priorityMap[p]) == 1 {
 			process := priorityMap[p][0]
@@ -273,32 +620,33 @@ func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
 
 			start := waitingTime
 
-			completion := process.BurstDuration + waitingTime
+			burst, completion, killed := runFor(process, start, process.BurstDuration)
 			lastCompletion = float64(completion)
 
 			turnaround := completion - process.ArrivalTime
 			totalTurnaround += float64(turnaround)
 
-			schedule[ct] = []string{
-				fmt.Sprint(process.ProcessID),
-				fmt.Sprint(process.Priority),
-				fmt.Sprint(process.BurstDuration),
-				fmt.Sprint(process.ArrivalTime),
-				fmt.Sprint(waitingTime),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
-			}
-			serviceTime += process.BurstDuration
-
-			gantt = append(gantt, TimeSlice{
-				PID:   process.ProcessID,
-				Start: start,
-				Stop:  serviceTime,
+			rows = append(rows, Row{
+				PID:        process.ProcessID,
+				Priority:   process.Priority,
+				Burst:      burst,
+				Arrival:    process.ArrivalTime,
+				Wait:       waitingTime,
+				Turnaround: turnaround,
+				Exit:       completion,
+				Killed:     killed,
 			})
+			serviceTime += burst
 
-			ct += 1
+			if burst > 0 {
+				gantt = append(gantt, TimeSlice{
+					PID:   process.ProcessID,
+					Start: start,
+					Stop:  serviceTime,
+				})
+			}
 		} else {
-			aTimesMap := make(map[int]Process)
+			aTimesMap := make(map[int]Process, len(priorityMap[p]))
 			aTimeNums := make([]int, 0, len(priorityMap[p]))
 			for _, v := range priorityMap[p] {
 				aTimesMap[int(v.ArrivalTime)] = v
@@ -307,6 +655,9 @@ func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
 			sort.Ints(aTimeNums)
 
 			for _, a := range aTimeNums {
+				if halted(ctx, limits, serviceTime, len(gantt)) {
+					break
+				}
 				if aTimesMap[a].ArrivalTime > 0 {
 					waitingTime = serviceTime
 				}
@@ -314,60 +665,68 @@ func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
 
 				start := waitingTime
 
-				completion := aTimesMap[a].BurstDuration + waitingTime
+				burst, completion, killed := runFor(aTimesMap[a], start, aTimesMap[a].BurstDuration)
 				lastCompletion = float64(completion)
 
 				turnaround := completion - aTimesMap[a].ArrivalTime
 				totalTurnaround += float64(turnaround)
 
-				schedule[ct] = []string{
-					fmt.Sprint(aTimesMap[a].ProcessID),
-					fmt.Sprint(aTimesMap[a].Priority),
-					fmt.Sprint(aTimesMap[a].BurstDuration),
-					fmt.Sprint(aTimesMap[a].ArrivalTime),
-					fmt.Sprint(waitingTime),
-					fmt.Sprint(turnaround),
-					fmt.Sprint(completion),
-				}
-				serviceTime += aTimesMap[a].BurstDuration
-
-				gantt = append(gantt, TimeSlice{
-					PID:   aTimesMap[a].ProcessID,
-					Start: start,
-					Stop:  serviceTime,
+				rows = append(rows, Row{
+					PID:        aTimesMap[a].ProcessID,
+					Priority:   aTimesMap[a].Priority,
+					Burst:      burst,
+					Arrival:    aTimesMap[a].ArrivalTime,
+					Wait:       waitingTime,
+					Turnaround: turnaround,
+					Exit:       completion,
+					Killed:     killed,
 				})
+				serviceTime += burst
 
-				ct += 1
+				if burst > 0 {
+					gantt = append(gantt, TimeSlice{
+						PID:   aTimesMap[a].ProcessID,
+						Start: start,
+						Stop:  serviceTime,
+					})
+				}
 			}
 
 		}
 	}
 
-	count := float64(ct)
+	if len(rows) == 0 {
+		// Stopped before anything ran; leave the stats at zero.
+		return Result{Rows: rows, Gantt: gantt}
+	}
+	count := float64(len(rows))
 	aveWait := totalWait / count
 	aveTurnaround := totalTurnaround / count
 	aveThroughput := count / lastCompletion
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return Result{
+		Rows:  rows,
+		Gantt: gantt,
+		Stats: Stats{AveWait: aveWait, AveTurnaround: aveTurnaround, Throughput: aveThroughput},
+	}
 }
 
-func SJFSchedule(w io.Writer, title string, processes []Process) {
+func SJFSchedule(ctx context.Context, processes []Process, limits Limits) Result {
 	var (
 		serviceTime     int64
 		totalWait       float64
 		totalTurnaround float64
 		lastCompletion  float64
 		waitingTime     int64
-		schedule        = make([][]string, 100)
+		rows            = make([]Row, 0, len(processes))
 		gantt           = make([]TimeSlice, 0)
 	)
+	if len(processes) == 0 {
+		return Result{Rows: rows, Gantt: gantt}
+	}
 
-	ct := 0
-
-	aTimesMap := make(map[int]Process)
-	processesLeft := make(map[int]Process)
+	aTimesMap := make(map[int]Process, len(processes))
+	processesLeft := make(map[int]Process, len(processes))
 	aTimeNums := make([]int, 0, len(processes))
 	for i := range processes {
 		aTimesMap[int(processes[i].ArrivalTime)] = processes[i]
@@ -393,76 +752,106 @@ func SJFSchedule(w io.Writer, title string, processes []Process) {
 		if i == 0 {
 			continue
 		}
+		if halted(ctx, limits, serviceTime, len(gantt)) {
+			// serviceTime has already moved on to this arrival, so emit the
+			// process still running since start for the partial result.
+			burst, completion, killed := runFor(currProcess, start, currProcess.BurstDuration)
+			rows = append(rows, Row{
+				PID:        currProcess.ProcessID,
+				Priority:   currProcess.Priority,
+				Burst:      burst,
+				Arrival:    currProcess.ArrivalTime,
+				Wait:       start,
+				Turnaround: completion - currProcess.ArrivalTime,
+				Exit:       completion,
+				Killed:     killed,
+			})
+			if burst > 0 {
+				gantt = append(gantt, TimeSlice{
+					PID:   currProcess.ProcessID,
+					Start: start,
+					Stop:  start + burst,
+				})
+			}
+			delete(processesLeft, int(currProcess.ProcessID))
+			break
+		}
 
 		if int(currProcess.BurstDuration)-a > int(aTimesMap[a].BurstDuration) {
 			waitingTime = start
 			totalWait += float64(waitingTime)
 
-			completion := a
+			burst, completion, killed := runFor(currProcess, start, int64(a)-start)
 			lastCompletion = float64(completion)
 
-			turnaround := completion - int(currProcess.ArrivalTime)
+			turnaround := completion - currProcess.ArrivalTime
 			totalTurnaround += float64(turnaround)
 
-			schedule[ct] = []string{
-				fmt.Sprint(currProcess.ProcessID),
-				fmt.Sprint(currProcess.Priority),
-				fmt.Sprint(completion - int(start)),
-				fmt.Sprint(currProcess.ArrivalTime),
-				fmt.Sprint(start),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
-			}
-
-			gantt = append(gantt, TimeSlice{
-				PID:   currProcess.ProcessID,
-				Start: start,
-				Stop:  serviceTime,
+			rows = append(rows, Row{
+				PID:        currProcess.ProcessID,
+				Priority:   currProcess.Priority,
+				Burst:      burst,
+				Arrival:    currProcess.ArrivalTime,
+				Wait:       start,
+				Turnaround: turnaround,
+				Exit:       completion,
+				Killed:     killed,
 			})
 
-			currProcess.BurstDuration -= int64(a)
-			processesLeft[int(currProcess.ProcessID)] = currProcess
+			if burst > 0 {
+				gantt = append(gantt, TimeSlice{
+					PID:   currProcess.ProcessID,
+					Start: start,
+					Stop:  start + burst,
+				})
+			}
+
+			if killed {
+				delete(processesLeft, int(currProcess.ProcessID))
+			} else {
+				currProcess.BurstDuration -= int64(a)
+				processesLeft[int(currProcess.ProcessID)] = currProcess
+			}
 
 			currProcess = aTimesMap[a]
 
 			start = int64(a)
-
-			ct += 1
 		} else if int(currProcess.BurstDuration)-a <= 0 || i == len(aTimeNums)-1 {
 			waitingTime = start
 			totalWait += float64(waitingTime)
 
-			completion := start + currProcess.BurstDuration
+			burst, completion, killed := runFor(currProcess, start, currProcess.BurstDuration)
 			lastCompletion = float64(completion)
 
 			turnaround := completion - currProcess.ArrivalTime
 			totalTurnaround += float64(turnaround)
 
-			schedule[ct] = []string{
-				fmt.Sprint(currProcess.ProcessID),
-				fmt.Sprint(currProcess.Priority),
-				fmt.Sprint(completion - start),
-				fmt.Sprint(currProcess.ArrivalTime),
-				fmt.Sprint(start),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
-			}
+			rows = append(rows, Row{
+				PID:        currProcess.ProcessID,
+				Priority:   currProcess.Priority,
+				Burst:      burst,
+				Arrival:    currProcess.ArrivalTime,
+				Wait:       start,
+				Turnaround: turnaround,
+				Exit:       completion,
+				Killed:     killed,
+			})
 
-			serviceTime = completion
+			serviceTime = start + burst
 
-			gantt = append(gantt, TimeSlice{
-				PID:   currProcess.ProcessID,
-				Start: start,
-				Stop:  serviceTime,
-			})
+			if burst > 0 {
+				gantt = append(gantt, TimeSlice{
+					PID:   currProcess.ProcessID,
+					Start: start,
+					Stop:  serviceTime,
+				})
+			}
 
 			delete(processesLeft, int(currProcess.ProcessID))
 
 			currProcess = aTimesMap[a]
 
 			start = int64(a)
-
-			ct += 1
 		}
 	}
 
@@ -485,6 +874,9 @@ func SJFSchedule(w io.Writer, title string, processes []Process) {
 
 	sort.Ints(keys)
 	for _, k := range keys {
+		if halted(ctx, limits, serviceTime, len(gantt)) {
+			break
+		}
 
 		if len(burstMap[k]) == 1 {
 			process := burstMap[k][0]
@@ -496,32 +888,33 @@ func SJFSchedule(w io.Writer, title string, processes []Process) {
 
 			start := waitingTime
 
-			completion := process.BurstDuration + waitingTime
+			burst, completion, killed := runFor(process, start, process.BurstDuration)
 			lastCompletion = float64(completion)
 
 			turnaround := completion - process.ArrivalTime
 			totalTurnaround += float64(turnaround)
 
-			schedule[ct] = []string{
-				fmt.Sprint(process.ProcessID),
-				fmt.Sprint(process.Priority),
-				fmt.Sprint(process.BurstDuration),
-				fmt.Sprint(process.ArrivalTime),
-				fmt.Sprint(waitingTime),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
-			}
-			serviceTime += process.BurstDuration
-
-			gantt = append(gantt, TimeSlice{
-				PID:   process.ProcessID,
-				Start: start,
-				Stop:  serviceTime,
+			rows = append(rows, Row{
+				PID:        process.ProcessID,
+				Priority:   process.Priority,
+				Burst:      burst,
+				Arrival:    process.ArrivalTime,
+				Wait:       waitingTime,
+				Turnaround: turnaround,
+				Exit:       completion,
+				Killed:     killed,
 			})
+			serviceTime += burst
 
-			ct += 1
+			if burst > 0 {
+				gantt = append(gantt, TimeSlice{
+					PID:   process.ProcessID,
+					Start: start,
+					Stop:  serviceTime,
+				})
+			}
 		} else {
-			aTimesMap := make(map[int]Process)
+			aTimesMap := make(map[int]Process, len(burstMap[k]))
 			aTimeNums := make([]int, 0, len(burstMap[k]))
 			for i := range burstMap[k] {
 				aTimesMap[int(burstMap[k][i].ArrivalTime)] = burstMap[k][i]
@@ -530,6 +923,9 @@ func SJFSchedule(w io.Writer, title string, processes []Process) {
 			sort.Ints(aTimeNums)
 
 			for _, a := range aTimeNums {
+				if halted(ctx, limits, serviceTime, len(gantt)) {
+					break
+				}
 				if aTimesMap[a].ArrivalTime > 0 {
 					waitingTime = serviceTime
 				}
@@ -537,58 +933,64 @@ func SJFSchedule(w io.Writer, title string, processes []Process) {
 
 				start := waitingTime
 
-				completion := aTimesMap[a].BurstDuration + waitingTime
+				burst, completion, killed := runFor(aTimesMap[a], start, aTimesMap[a].BurstDuration)
 				lastCompletion = float64(completion)
 
 				turnaround := completion - aTimesMap[a].ArrivalTime
 				totalTurnaround += float64(turnaround)
 
-				schedule[ct] = []string{
-					fmt.Sprint(aTimesMap[a].ProcessID),
-					fmt.Sprint(aTimesMap[a].Priority),
-					fmt.Sprint(aTimesMap[a].BurstDuration),
-					fmt.Sprint(aTimesMap[a].ArrivalTime),
-					fmt.Sprint(waitingTime),
-					fmt.Sprint(turnaround),
-					fmt.Sprint(completion),
-				}
-				serviceTime += aTimesMap[a].BurstDuration
-
-				gantt = append(gantt, TimeSlice{
-					PID:   aTimesMap[a].ProcessID,
-					Start: start,
-					Stop:  serviceTime,
+				rows = append(rows, Row{
+					PID:        aTimesMap[a].ProcessID,
+					Priority:   aTimesMap[a].Priority,
+					Burst:      burst,
+					Arrival:    aTimesMap[a].ArrivalTime,
+					Wait:       waitingTime,
+					Turnaround: turnaround,
+					Exit:       completion,
+					Killed:     killed,
 				})
+				serviceTime += burst
 
-				ct += 1
+				if burst > 0 {
+					gantt = append(gantt, TimeSlice{
+						PID:   aTimesMap[a].ProcessID,
+						Start: start,
+						Stop:  serviceTime,
+					})
+				}
 			}
 
 		}
 	}
 
-	count := float64(ct)
+	if len(rows) == 0 {
+		// Stopped before anything ran; leave the stats at zero.
+		return Result{Rows: rows, Gantt: gantt}
+	}
+	count := float64(len(rows))
 	aveWait := totalWait / count
 	aveTurnaround := totalTurnaround / count
 	aveThroughput := count / lastCompletion
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return Result{
+		Rows:  rows,
+		Gantt: gantt,
+		Stats: Stats{AveWait: aveWait, AveTurnaround: aveTurnaround, Throughput: aveThroughput},
+	}
 }
 
-func RRSchedule(w io.Writer, title string, processes []Process) {
+func RRSchedule(ctx context.Context, processes []Process, limits Limits) Result {
 	var (
 		serviceTime     int64
 		totalWait       float64
 		totalTurnaround float64
 		lastCompletion  float64
 		waitingTime     int64
-		schedule        = make([][]string, 100)
+		rows            = make([]Row, 0, len(processes))
 		gantt           = make([]TimeSlice, 0)
 	)
-	ct := 0
 	var timeQ int64 = 3 //time quantum of 3
-	remProcesses := make(map[int64]Process)
+	remProcesses := make(map[int64]Process, len(processes))
 
 	for _, p := range processes {
 		remProcesses[p.ProcessID] = p
@@ -598,9 +1000,12 @@ func RRSchedule(w io.Writer, title string, processes []Process) {
 		return processes[i].ArrivalTime < processes[j].ArrivalTime
 	})
 
-	for len(remProcesses) > 0 {
+	for len(remProcesses) > 0 && !halted(ctx, limits, serviceTime, len(gantt)) {
 
 		for _, p := range processes {
+			if halted(ctx, limits, serviceTime, len(gantt)) {
+				break
+			}
 
 			process, ok := remProcesses[p.ProcessID]
 
@@ -615,60 +1020,63 @@ func RRSchedule(w io.Writer, title string, processes []Process) {
 
 			if process.BurstDuration < timeQ {
 
-				completion := waitingTime + process.BurstDuration
+				burst, completion, killed := runFor(process, start, process.BurstDuration)
 				lastCompletion = float64(completion)
 
 				turnaround := completion - process.ArrivalTime
 				totalTurnaround += float64(turnaround)
 
-				schedule[ct] = []string{
-					fmt.Sprint(process.ProcessID),
-					fmt.Sprint(process.Priority),
-					fmt.Sprint(process.BurstDuration),
-					fmt.Sprint(process.ArrivalTime),
-					fmt.Sprint(waitingTime),
-					fmt.Sprint(turnaround),
-					fmt.Sprint(completion),
-				}
-				ct += 1
-				serviceTime += process.BurstDuration
-
-				gantt = append(gantt, TimeSlice{
-					PID:   process.ProcessID,
-					Start: start,
-					Stop:  serviceTime,
+				rows = append(rows, Row{
+					PID:        process.ProcessID,
+					Priority:   process.Priority,
+					Burst:      burst,
+					Arrival:    process.ArrivalTime,
+					Wait:       waitingTime,
+					Turnaround: turnaround,
+					Exit:       completion,
+					Killed:     killed,
 				})
+				serviceTime += burst
+
+				if burst > 0 {
+					gantt = append(gantt, TimeSlice{
+						PID:   process.ProcessID,
+						Start: start,
+						Stop:  serviceTime,
+					})
+				}
 
 				delete(remProcesses, process.ProcessID)
 
 			} else {
-				burst := timeQ
-				completion := waitingTime + burst
+				burst, completion, killed := runFor(process, start, timeQ)
 				lastCompletion = float64(completion)
 
 				turnaround := completion - process.ArrivalTime
 				totalTurnaround += float64(turnaround)
 
-				schedule[ct] = []string{
-					fmt.Sprint(process.ProcessID),
-					fmt.Sprint(process.Priority),
-					fmt.Sprint(burst),
-					fmt.Sprint(process.ArrivalTime),
-					fmt.Sprint(waitingTime),
-					fmt.Sprint(turnaround),
-					fmt.Sprint(completion),
-				}
-				ct += 1
+				rows = append(rows, Row{
+					PID:        process.ProcessID,
+					Priority:   process.Priority,
+					Burst:      burst,
+					Arrival:    process.ArrivalTime,
+					Wait:       waitingTime,
+					Turnaround: turnaround,
+					Exit:       completion,
+					Killed:     killed,
+				})
 				serviceTime += burst
 
-				gantt = append(gantt, TimeSlice{
-					PID:   process.ProcessID,
-					Start: start,
-					Stop:  serviceTime,
-				})
+				if burst > 0 {
+					gantt = append(gantt, TimeSlice{
+						PID:   process.ProcessID,
+						Start: start,
+						Stop:  serviceTime,
+					})
+				}
 
 				process.BurstDuration -= burst
-				if process.BurstDuration == 0 {
+				if process.BurstDuration == 0 || killed {
 					delete(remProcesses, process.ProcessID)
 				} else {
 					remProcesses[process.ProcessID] = process
@@ -678,80 +1086,28 @@ func RRSchedule(w io.Writer, title string, processes []Process) {
 		}
 	}
 
-	count := float64(ct)
+	if len(rows) == 0 {
+		// Stopped before anything ran; leave the stats at zero.
+		return Result{Rows: rows, Gantt: gantt}
+	}
+	count := float64(len(rows))
 	aveWait := totalWait / count
 	aveTurnaround := totalTurnaround / count
 	aveThroughput := count / lastCompletion
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
-
-//endregion
-
-//region Output helpers
-
-func outputTitle(w io.Writer, title string) {
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-}
-
-func outputGantt(w io.Writer, gantt []TimeSlice) {
-	_, _ = fmt.Fprintln(w, "Gantt schedule")
-	_, _ = fmt.Fprint(w, "|")
-	for i := range gantt {
-		pid := fmt.Sprint(gantt[i].PID)
-		padding := strings.Repeat(" ", (8-len(pid))/2)
-		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
-	}
-	_, _ = fmt.Fprintln(w)
-	for i := range gantt {
-		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
-		if len(gantt)-1 == i {
-			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
-		}
+	return Result{
+		Rows:  rows,
+		Gantt: gantt,
+		Stats: Stats{AveWait: aveWait, AveTurnaround: aveTurnaround, Throughput: aveThroughput},
 	}
-	_, _ = fmt.Fprintf(w, "\n\n")
-}
-
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
-	_, _ = fmt.Fprintln(w, "Schedule table")
-	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
-	table.AppendBulk(rows)
-	table.SetFooter([]string{"", "", "", "",
-		fmt.Sprintf("Average\n%.2f", wait),
-		fmt.Sprintf("Average\n%.2f", turnaround),
-		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
-	table.Render()
 }
 
 //endregion
 
 //region Loading processes.
 
-var ErrInvalidArgs = errors.New("invalid args")
-
-func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
-	}
-
-	processes := make([]Process, len(rows))
-	for i := range rows {
-		processes[i].ProcessID = mustStrToInt(rows[i][0])
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
-		}
-	}
-
-	return processes, nil
-}
+// ErrInvalidArgs is wrapped by every error caused by invalid input.
+var ErrInvalidArgs = sim.ErrInvalidArgs
 
 func mustStrToInt(s string) int64 {
 	i, err := strconv.ParseInt(s, 10, 64)
@@ -0,0 +1,9 @@
+package main
+
+// workload is three processes that arrive while the first is running, with
+// the second having the best priority.
+var workload = []Process{
+	{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+	{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+	{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2, Priority: 3},
+}
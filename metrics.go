@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+	"github.com/olekukonko/tablewriter"
+)
+
+// Metric is an aggregate measure of a scheduler's Result.
+type Metric interface {
+	Compute(r Result) float64
+}
+
+// MetricFunc adapts an ordinary function to the Metric interface.
+type MetricFunc func(r Result) float64
+
+func (f MetricFunc) Compute(r Result) float64 {
+	return f(r)
+}
+
+var metrics = make(map[string]Metric)
+
+func init() {
+	RegisterMetric("wait", MetricFunc(func(r Result) float64 { return r.Stats.AveWait }))
+	RegisterMetric("turnaround", MetricFunc(func(r Result) float64 { return r.Stats.AveTurnaround }))
+	RegisterMetric("throughput", MetricFunc(func(r Result) float64 { return r.Stats.Throughput }))
+	RegisterMetric("switches", MetricFunc(func(r Result) float64 { return float64(contextSwitches(r.Gantt)) }))
+	RegisterMetric("makespan", MetricFunc(makespan))
+	RegisterMetric("utilization", MetricFunc(utilization))
+	RegisterMetric("max-wait", MetricFunc(maxWait))
+}
+
+// RegisterMetric makes m selectable under name. Registering a name twice
+// replaces the earlier registration.
+func RegisterMetric(name string, m Metric) {
+	metrics[name] = m
+}
+
+// SelectMetrics returns the names in a comma-separated list, checking that
+// each is a registered metric.
+func SelectMetrics(list string) ([]string, error) {
+	var selected []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := metrics[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown metric %q (have %s)", ErrInvalidArgs, name, strings.Join(metricNames(), ", "))
+		}
+		selected = append(selected, name)
+	}
+
+	return selected, nil
+}
+
+func metricNames() []string {
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// computeMetrics evaluates each of the named metrics on r, keyed by name.
+func computeMetrics(names []string, r Result) map[string]float64 {
+	values := make(map[string]float64, len(names))
+	for _, name := range names {
+		values[name] = metrics[name].Compute(r)
+	}
+
+	return values
+}
+
+// makespan is the time the last slice finished.
+func makespan(r Result) float64 {
+	var end int64
+	for _, g := range r.Gantt {
+		if g.Stop > end {
+			end = g.Stop
+		}
+	}
+
+	return float64(end)
+}
+
+// utilization is the fraction of the makespan for which the CPU was busy.
+func utilization(r Result) float64 {
+	span := makespan(r)
+	if span == 0 {
+		return 0
+	}
+	var busy int64
+	for _, g := range r.Gantt {
+		busy += g.Stop - g.Start
+	}
+
+	return float64(busy) / span
+}
+
+// maxWait is the longest wait of any row.
+func maxWait(r Result) float64 {
+	var longest int64
+	for _, row := range r.Rows {
+		if row.Wait > longest {
+			longest = row.Wait
+		}
+	}
+
+	return float64(longest)
+}
+
+// MetricsReport outputs a table of every selected metric for each algorithm.
+func MetricsReport(w io.Writer, names []string, titles []string, results []Result) {
+	header := append([]string{"Algorithm"}, names...)
+
+	render.Title(w, "Metrics")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	for i, r := range results {
+		row := []string{titles[i]}
+		for _, name := range names {
+			row = append(row, fmt.Sprintf("%.2f", metrics[name].Compute(r)))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// placements are the ways a partitioned multi-CPU run assigns an arriving
+// process to a CPU:
+//   - jsq joins the CPU with the fewest unfinished processes,
+//   - jiq joins an idle CPU if there is one and a random CPU otherwise,
+//   - rr deals processes out to the CPUs in turn.
+var placements = map[string]bool{"jsq": true, "jiq": true, "rr": true}
+
+func checkPlacement(placement string) error {
+	if !placements[placement] {
+		return fmt.Errorf("%w: unknown placement %q", ErrInvalidArgs, placement)
+	}
+
+	return nil
+}
+
+// PartitionedSchedule returns a scheduler for cpus CPUs, each running its
+// own copy of schedule. Processes are placed on a CPU when they arrive and
+// never migrate. The result's rows gain a CPU column and its Gantt chart is
+// split per CPU; seed drives jiq's random choices.
+func PartitionedSchedule(cpus int, placement string, seed int64, schedule func(ctx context.Context, processes []Process, limits Limits) Result) func(ctx context.Context, processes []Process, limits Limits) Result {
+	return func(ctx context.Context, processes []Process, limits Limits) Result {
+		rng := rand.New(rand.NewSource(seed))
+
+		arrivals := copyProcesses(processes)
+		sort.SliceStable(arrivals, func(i, j int) bool {
+			return arrivals[i].ArrivalTime < arrivals[j].ArrivalTime
+		})
+
+		assigned := make([][]Process, cpus)
+		queues := make([]cpuQueue, cpus)
+		for i, p := range arrivals {
+			if ctx.Err() != nil {
+				break
+			}
+			cpu := i % cpus
+			if placement != "rr" {
+				queued := make([]int, cpus)
+				for c := range queues {
+					queued[c] = queues[c].unfinishedAt(ctx, schedule, p.ArrivalTime, limits)
+				}
+				cpu = placeOn(placement, queued, rng)
+				queues[cpu].add(p, queued[cpu] == 0)
+			}
+			assigned[cpu] = append(assigned[cpu], p)
+		}
+
+		var r Result
+		r.CPUGantts = make([][]TimeSlice, cpus)
+		for c := range assigned {
+			if len(assigned[c]) == 0 {
+				continue
+			}
+			part := schedule(ctx, assigned[c], limits)
+			if r.ExtraColumns == nil {
+				r.ExtraColumns = append([]string{"CPU"}, part.ExtraColumns...)
+			}
+			for _, row := range part.Rows {
+				row.Extra = append([]string{fmt.Sprint(c)}, row.Extra...)
+				r.Rows = append(r.Rows, row)
+			}
+			r.Gantt = append(r.Gantt, part.Gantt...)
+			r.CPUGantts[c] = part.Gantt
+			r.DeviceGantt = append(r.DeviceGantt, part.DeviceGantt...)
+			for _, e := range part.Trace {
+				e.Detail = strings.TrimSuffix(fmt.Sprintf("cpu %d: %s", c, e.Detail), ": ")
+				r.Trace = append(r.Trace, e)
+			}
+			if r.Truncated == "" {
+				r.Truncated = part.Truncated
+			}
+		}
+		if r.ExtraColumns == nil {
+			r.ExtraColumns = []string{"CPU"}
+		}
+		sort.SliceStable(r.Gantt, func(i, j int) bool {
+			return r.Gantt[i].Start < r.Gantt[j].Start
+		})
+		sort.SliceStable(r.DeviceGantt, func(i, j int) bool {
+			return r.DeviceGantt[i].Start < r.DeviceGantt[j].Start
+		})
+		sort.SliceStable(r.Trace, func(i, j int) bool {
+			return r.Trace[i].Time < r.Trace[j].Time
+		})
+		r.Stats = summarize(r.Rows)
+
+		return r
+	}
+}
+
+// placeOn picks the CPU for an arriving process given how many unfinished
+// processes each CPU has queued.
+func placeOn(placement string, queued []int, rng *rand.Rand) int {
+	if placement == "jiq" {
+		for c, n := range queued {
+			if n == 0 {
+				return c
+			}
+		}
+		return rng.Intn(len(queued))
+	}
+
+	shortest := 0
+	for c, n := range queued {
+		if n < queued[shortest] {
+			shortest = c
+		}
+	}
+
+	return shortest
+}
+
+// cpuQueue tracks the processes placed on one CPU so jsq and jiq can count
+// how many are still in the system. Once a CPU has drained, what ran before
+// can't affect what runs next, so only the processes placed since it was
+// last idle are simulated, and a CPU whose processes haven't changed reuses
+// its last simulation. Each arrival
+// then costs one simulation of a busy period instead of one of everything
+// placed on every CPU.
+type cpuQueue struct {
+	busy []Process
+	// exits maps each process in busy to when it last leaves the CPU, or is
+	// nil if busy has changed since it was simulated.
+	exits map[int64]int64
+}
+
+// add places p on the CPU, starting a new busy period if it is idle.
+func (q *cpuQueue) add(p Process, idle bool) {
+	if idle {
+		q.busy = q.busy[:0]
+	}
+	q.busy = append(q.busy, p)
+	q.exits = nil
+}
+
+// unfinishedAt counts how many of the processes placed on the CPU are still
+// in the system at time t, by scheduling the current busy period on its own.
+func (q *cpuQueue) unfinishedAt(ctx context.Context, schedule func(ctx context.Context, processes []Process, limits Limits) Result, t int64, limits Limits) int {
+	if len(q.busy) == 0 {
+		return 0
+	}
+
+	if q.exits == nil {
+		q.exits = make(map[int64]int64, len(q.busy))
+		for _, row := range schedule(ctx, copyProcesses(q.busy), limits).Rows {
+			if row.Exit > q.exits[row.PID] {
+				q.exits[row.PID] = row.Exit
+			}
+		}
+	}
+	// Anything the schedule never reached is still waiting.
+	unfinished := len(q.busy) - len(q.exits)
+	for _, exit := range q.exits {
+		if exit > t {
+			unfinished++
+		}
+	}
+
+	return unfinished
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestPartitionedScheduleKeepsPerCPUOutput(t *testing.T) {
+	guaranteed := PartitionedSchedule(2, "jsq", 1, GuaranteedSchedule)(context.Background(), workload, Limits{})
+	if want := []string{"CPU", "Consumed/entitled"}; !reflect.DeepEqual(guaranteed.ExtraColumns, want) {
+		t.Errorf("extra columns = %q, want %q", guaranteed.ExtraColumns, want)
+	}
+	for _, row := range guaranteed.Rows {
+		if len(row.Extra) == 0 || len(row.Extra) > len(guaranteed.ExtraColumns) {
+			t.Errorf("row %+v doesn't match the columns", row)
+		}
+	}
+
+	aging := PartitionedSchedule(2, "jsq", 1, AgingPrioritySchedule(2))(context.Background(), workload, Limits{})
+	if len(aging.Trace) == 0 {
+		t.Fatal("per-CPU traces were dropped")
+	}
+	for i := 1; i < len(aging.Trace); i++ {
+		if aging.Trace[i].Time < aging.Trace[i-1].Time {
+			t.Errorf("trace out of order at event %d", i)
+		}
+	}
+}
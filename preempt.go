@@ -0,0 +1,252 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"sort"
+)
+
+// job is a process waiting for or holding the CPU under a preemptive
+// scheduler, along with how much of its burst is left.
+type job struct {
+	Process
+	remaining int64
+	// entitled is the CPU time the job has been owed so far, for policies
+	// that track it.
+	entitled float64
+	// seq orders jobs by when they became ready, breaking ties between jobs
+	// the policy ranks equally.
+	seq int
+	// index is the job's position in the ready queue, or -1 when it isn't
+	// queued.
+	index int
+}
+
+// readyQueue is a heap of the jobs waiting for the CPU, best first by the
+// policy's ranking and then by seq.
+type readyQueue struct {
+	jobs   []*job
+	better func(a, b *job) bool
+}
+
+func (q *readyQueue) Len() int {
+	return len(q.jobs)
+}
+
+func (q *readyQueue) Less(i, j int) bool {
+	a, b := q.jobs[i], q.jobs[j]
+	if q.better(a, b) {
+		return true
+	}
+	if q.better(b, a) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+func (q *readyQueue) Swap(i, j int) {
+	q.jobs[i], q.jobs[j] = q.jobs[j], q.jobs[i]
+	q.jobs[i].index = i
+	q.jobs[j].index = j
+}
+
+func (q *readyQueue) Push(x any) {
+	j := x.(*job)
+	j.index = len(q.jobs)
+	q.jobs = append(q.jobs, j)
+}
+
+func (q *readyQueue) Pop() any {
+	last := len(q.jobs) - 1
+	j := q.jobs[last]
+	q.jobs[last] = nil
+	q.jobs = q.jobs[:last]
+	j.index = -1
+	return j
+}
+
+// killQueue is a heap of jobs with a kill time, soonest first. Jobs are left
+// in it after they finish and skipped when they come out.
+type killQueue []*job
+
+func (q killQueue) Len() int {
+	return len(q)
+}
+
+func (q killQueue) Less(i, j int) bool {
+	if q[i].KillTime != q[j].KillTime {
+		return q[i].KillTime < q[j].KillTime
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q killQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *killQueue) Push(x any) {
+	*q = append(*q, x.(*job))
+}
+
+func (q *killQueue) Pop() any {
+	old := *q
+	j := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return j
+}
+
+// preemptivePolicy decides which job a preemptive scheduler runs.
+type preemptivePolicy struct {
+	// better reports whether a should be dispatched before b.
+	better func(a, b *job) bool
+	// preempts reports whether candidate should take the CPU from running.
+	preempts func(candidate, running *job) bool
+	// tick, when positive, limits preemption checks to multiples of tick.
+	tick int64
+	// elapsed, if set, is told each time dt time units pass with ready in
+	// the system, the running job included. Since it may change how jobs
+	// rank, the ready queue is re-sorted after every call.
+	elapsed func(ready []*job, dt int64)
+}
+
+// ThresholdPrioritySchedule returns a preemptive priority scheduler (lower
+// numbers run first) in which a newly ready process only preempts the running
+// one if its priority is better by more than threshold levels. Raising the
+// threshold trades priority inversion for fewer context switches, as in
+// RTOS preemption-threshold scheduling; a threshold of 0 is plain preemptive
+// priority.
+func ThresholdPrioritySchedule(threshold int64) func(ctx context.Context, processes []Process, limits Limits) Result {
+	return func(ctx context.Context, processes []Process, limits Limits) Result {
+		return preemptive(ctx, processes, preemptivePolicy{
+			better: func(a, b *job) bool {
+				return a.Priority < b.Priority
+			},
+			preempts: func(candidate, running *job) bool {
+				return running.Priority-candidate.Priority > threshold
+			},
+		}, limits)
+	}
+}
+
+// preemptive runs a preemptive scheduler driven by policy, re-evaluating the
+// running process at every arrival, or only at multiples of the policy's
+// tick if it has one. It emits one row per slice, waiting time being the
+// slice's start and turnaround its stop, both less the arrival time. Waiting jobs are kept in heaps, so each decision
+// costs O(log n) in the number of ready jobs unless the policy has an
+// elapsed hook.
+func preemptive(ctx context.Context, processes []Process, policy preemptivePolicy, limits Limits) Result {
+	pending := copyProcesses(processes)
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].ArrivalTime < pending[j].ArrivalTime
+	})
+
+	var (
+		now        int64
+		sliceStart int64
+		current    *job
+		seq        int
+		ready      = &readyQueue{better: policy.better}
+		kills      = &killQueue{}
+		all        []*job
+		rows       = make([]Row, 0, len(processes))
+		gantt      = make([]TimeSlice, 0, len(processes))
+	)
+	addRow := func(p Process, start, stop int64, killed bool) {
+		rows = append(rows, Row{
+			PID:        p.ProcessID,
+			Priority:   p.Priority,
+			Burst:      stop - start,
+			Arrival:    p.ArrivalTime,
+			Wait:       start - p.ArrivalTime,
+			Turnaround: stop - p.ArrivalTime,
+			Exit:       stop,
+			Killed:     killed,
+		})
+	}
+	closeSlice := func(killed bool) {
+		if current == nil {
+			return
+		}
+		if now > sliceStart {
+			gantt = append(gantt, TimeSlice{
+				PID:   current.ProcessID,
+				Start: sliceStart,
+				Stop:  now,
+			})
+		}
+		addRow(current.Process, sliceStart, now, killed)
+		current = nil
+	}
+
+	for len(pending) > 0 || ready.Len() > 0 || current != nil {
+		if halted(ctx, limits, now, len(gantt)) {
+			closeSlice(false)
+			break
+		}
+		for len(pending) > 0 && pending[0].ArrivalTime <= now {
+			j := &job{Process: pending[0], remaining: pending[0].BurstDuration, seq: seq}
+			seq++
+			heap.Push(ready, j)
+			if j.KillTime > 0 {
+				heap.Push(kills, j)
+			}
+			pending = pending[1:]
+		}
+
+		// Drop processes that were killed while waiting. The running one is
+		// killed by runFor, but may yet be preempted first.
+		var running *job
+		for kills.Len() > 0 && (*kills)[0].KillTime <= now {
+			j := heap.Pop(kills).(*job)
+			switch {
+			case j == current:
+				running = j
+			case j.index >= 0:
+				heap.Remove(ready, j.index)
+				addRow(j.Process, j.KillTime, j.KillTime, true)
+			}
+		}
+		if running != nil {
+			heap.Push(kills, running)
+		}
+
+		if ready.Len() == 0 && current == nil {
+			if len(pending) > 0 {
+				now = pending[0].ArrivalTime
+			}
+			continue
+		}
+
+		if ready.Len() > 0 && (current == nil || policy.preempts(ready.jobs[0], current)) {
+			next := heap.Pop(ready).(*job)
+			if preempted := current; preempted != nil {
+				closeSlice(false)
+				heap.Push(ready, preempted)
+			}
+			current, sliceStart = next, now
+		}
+
+		// Run until the next point at which a preemption may be considered.
+		until := now + current.remaining
+		if tick := policy.tick; tick > 0 && (now/tick+1)*tick < until {
+			until = (now/tick + 1) * tick
+		} else if tick == 0 && len(pending) > 0 && pending[0].ArrivalTime < until {
+			until = pending[0].ArrivalTime
+		}
+		burst, stop, killed := runFor(current.Process, now, until-now)
+		current.remaining -= burst
+		if policy.elapsed != nil {
+			all = append(append(all[:0], ready.jobs...), current)
+			policy.elapsed(all, stop-now)
+			heap.Init(ready)
+		}
+		now = stop
+
+		if killed || current.remaining == 0 {
+			closeSlice(killed)
+		}
+	}
+
+	return Result{Rows: rows, Gantt: gantt, Stats: summarize(rows)}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestThresholdPrioritySchedule(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int64
+		gantt     []TimeSlice
+		waits     []int64
+	}{
+		{
+			name:      "plain preemptive priority",
+			threshold: 0,
+			gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 4}, {PID: 1, Start: 4, Stop: 7}, {PID: 3, Start: 7, Stop: 9}},
+			waits:     []int64{0, 0, 4, 5},
+		},
+		{
+			name:      "threshold stops a one-level preemption",
+			threshold: 1,
+			gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 7}, {PID: 3, Start: 7, Stop: 9}},
+			waits:     []int64{0, 3, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Scheduler{Schedule: ThresholdPrioritySchedule(tt.threshold)}.Run(context.Background(), workload, Limits{})
+			if !reflect.DeepEqual(r.Gantt, tt.gantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.gantt)
+			}
+			// Each slice waits from its process's arrival.
+			var waits []int64
+			for _, row := range r.Rows {
+				waits = append(waits, row.Wait)
+			}
+			if !reflect.DeepEqual(waits, tt.waits) {
+				t.Errorf("waits = %v, want %v", waits, tt.waits)
+			}
+		})
+	}
+}
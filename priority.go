@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+	"github.com/olekukonko/tablewriter"
+)
+
+// PriorityReport outputs each algorithm's average wait and turnaround per
+// priority level, averaged over rows the same way as the schedule table, and
+// how far each level's wait is from the algorithm's overall average. A
+// policy that favours high priorities (low numbers) shows waits below
+// average at the top levels and above it at the bottom.
+func PriorityReport(w io.Writer, titles []string, results []Result) {
+	render.Title(w, "Averages by priority level")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Priority", "Processes", "Wait", "Turnaround", "Wait vs. average"})
+	for i, r := range results {
+		levels := make(map[int64][]Row)
+		pids := make(map[int64]map[int64]bool)
+		for _, row := range r.Rows {
+			levels[row.Priority] = append(levels[row.Priority], row)
+			if pids[row.Priority] == nil {
+				pids[row.Priority] = make(map[int64]bool)
+			}
+			pids[row.Priority][row.PID] = true
+		}
+		order := make([]int64, 0, len(levels))
+		for level := range levels {
+			order = append(order, level)
+		}
+		sort.Slice(order, func(a, b int) bool { return order[a] < order[b] })
+
+		for j, level := range order {
+			stats := summarize(levels[level])
+			title := ""
+			if j == 0 {
+				title = titles[i]
+			}
+			table.Append([]string{
+				title,
+				fmt.Sprint(level),
+				fmt.Sprint(len(pids[level])),
+				fmt.Sprintf("%.2f", stats.AveWait),
+				fmt.Sprintf("%.2f", stats.AveTurnaround),
+				fmt.Sprintf("%+.2f", stats.AveWait-r.Stats.AveWait),
+			})
+		}
+	}
+	table.Render()
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+)
+
+// RandomSchedule returns a non-preemptive scheduler that dispatches a
+// uniformly random ready process each time the CPU frees up. It makes a
+// baseline for the deliberate policies; every run with the same seed picks
+// the same processes.
+func RandomSchedule(seed int64) func(ctx context.Context, processes []Process, limits Limits) Result {
+	return func(ctx context.Context, processes []Process, limits Limits) Result {
+		rng := rand.New(rand.NewSource(seed))
+
+		pending := copyProcesses(processes)
+		sort.SliceStable(pending, func(i, j int) bool {
+			return pending[i].ArrivalTime < pending[j].ArrivalTime
+		})
+
+		// The ready processes are those arrived and not yet run, in arrival
+		// order; a Fenwick tree over arrival order finds the i-th of them
+		// without shifting a ready list on every dispatch.
+		var (
+			now     int64
+			arrived int
+			ready   = newFenwick(len(pending))
+			waiting int
+			rows    = make([]Row, 0, len(processes))
+			gantt   = make([]TimeSlice, 0)
+		)
+		for arrived < len(pending) || waiting > 0 {
+			if halted(ctx, limits, now, len(gantt)) {
+				break
+			}
+			for arrived < len(pending) && pending[arrived].ArrivalTime <= now {
+				ready.add(arrived, 1)
+				arrived++
+				waiting++
+			}
+			if waiting == 0 {
+				now = pending[arrived].ArrivalTime
+				continue
+			}
+
+			i := ready.find(rng.Intn(waiting) + 1)
+			ready.add(i, -1)
+			waiting--
+			process := pending[i]
+
+			start := now
+			burst, stop, killed := runFor(process, start, process.BurstDuration)
+			if burst == 0 {
+				// Killed while waiting; it never held the CPU.
+				start = stop
+			} else {
+				now = stop
+				gantt = append(gantt, TimeSlice{
+					PID:   process.ProcessID,
+					Start: start,
+					Stop:  stop,
+				})
+			}
+
+			rows = append(rows, Row{
+				PID:        process.ProcessID,
+				Priority:   process.Priority,
+				Burst:      burst,
+				Arrival:    process.ArrivalTime,
+				Wait:       start - process.ArrivalTime,
+				Turnaround: stop - process.ArrivalTime,
+				Exit:       stop,
+				Killed:     killed,
+			})
+		}
+
+		return Result{Rows: rows, Gantt: gantt, Stats: summarize(rows)}
+	}
+}
+
+// fenwick is a binary indexed tree of counts, supporting point updates and
+// finding the position of the k-th counted item in O(log n).
+type fenwick []int
+
+func newFenwick(n int) fenwick {
+	return make(fenwick, n+1)
+}
+
+// add adds delta to the count at position i, counting from 0.
+func (f fenwick) add(i, delta int) {
+	for i++; i < len(f); i += i & -i {
+		f[i] += delta
+	}
+}
+
+// find returns the position of the k-th counted item, counting k from 1.
+func (f fenwick) find(k int) int {
+	var pos int
+	step := 1
+	for step*2 < len(f) {
+		step *= 2
+	}
+	for ; step > 0; step /= 2 {
+		if next := pos + step; next < len(f) && f[next] < k {
+			pos = next
+			k -= f[next]
+		}
+	}
+
+	return pos
+}
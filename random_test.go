@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRandomSchedule(t *testing.T) {
+	run := func(seed int64) Result {
+		return Scheduler{Schedule: RandomSchedule(seed)}.Run(context.Background(), workload, Limits{})
+	}
+
+	r := run(7)
+	if !reflect.DeepEqual(r, run(7)) {
+		t.Error("the same seed gave different schedules")
+	}
+	// Non-preemptive: one row per process, dispatched no earlier than its
+	// arrival, with wait measured from arrival.
+	if len(r.Rows) != len(workload) {
+		t.Fatalf("got %d rows, want %d", len(r.Rows), len(workload))
+	}
+	var busyUntil int64
+	for i, row := range r.Rows {
+		if row.Start() < row.Arrival || row.Start() < busyUntil {
+			t.Errorf("row %d starts at %d, before arrival %d or the CPU was free at %d", i, row.Start(), row.Arrival, busyUntil)
+		}
+		if row.Wait != row.Start()-row.Arrival {
+			t.Errorf("row %d: wait %d, want %d", i, row.Wait, row.Start()-row.Arrival)
+		}
+		if row.Turnaround != row.Exit-row.Arrival {
+			t.Errorf("row %d: turnaround %d, want %d", i, row.Turnaround, row.Exit-row.Arrival)
+		}
+		busyUntil = row.Exit
+	}
+}
@@ -0,0 +1,378 @@
+// Package render outputs scheduling results as text for people: Gantt
+// charts, schedule tables and the reports built on them.
+package render
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jonuorah26/CSCE4600-Project1/sim"
+	"github.com/olekukonko/tablewriter"
+)
+
+// Options selects the optional parts of Result's output.
+type Options struct {
+	// Trace outputs the result's event trace, if it has one.
+	Trace bool
+	// Annotate adds each slice's note, such as its effective priority, to
+	// its Gantt cell.
+	Annotate bool
+	// RulerMajor, when positive, draws Gantt charts to scale over a time
+	// axis with a labelled major tick every RulerMajor time units and a
+	// minor tick every RulerMinor, if positive.
+	RulerMajor int64
+	RulerMinor int64
+	// Resolution is the number of time units per character of a to-scale
+	// Gantt chart; below 1 it is taken as 1.
+	Resolution int64
+}
+
+// ParseRuler parses a -ruler value of a major tick interval and an optional
+// minor one, such as "10" or "10,2".
+func ParseRuler(s string) (major, minor int64, err error) {
+	majorText, minorText, hasMinor := strings.Cut(s, ",")
+	if major, err = strconv.ParseInt(majorText, 10, 64); err != nil || major <= 0 {
+		return 0, 0, fmt.Errorf("%w: ruler needs a positive major tick interval, not %q", sim.ErrInvalidArgs, majorText)
+	}
+	if hasMinor {
+		if minor, err = strconv.ParseInt(minorText, 10, 64); err != nil || minor <= 0 {
+			return 0, 0, fmt.Errorf("%w: ruler needs a positive minor tick interval, not %q", sim.ErrInvalidArgs, minorText)
+		}
+	}
+
+	return major, minor, nil
+}
+
+// Result outputs a scheduler's result as a titled Gantt chart followed by
+// the schedule table.
+func Result(w io.Writer, title string, r sim.Result, opts Options) {
+	Title(w, title)
+	if r.CPUGantts != nil {
+		for c, gantt := range r.CPUGantts {
+			Gantt(w, fmt.Sprintf("CPU %d Gantt schedule", c), gantt, opts)
+		}
+	} else {
+		Gantt(w, "Gantt schedule", r.Gantt, opts)
+	}
+	if r.DeviceGantt != nil {
+		Gantt(w, "Device schedule", r.DeviceGantt, opts)
+		outputUtilization(w, r)
+	}
+	outputSchedule(w, r.Rows, r.ExtraColumns, r.Stats)
+	if len(r.Series) > 0 {
+		outputSeries(w, r.Series)
+	}
+	if r.Truncated != "" {
+		_, _ = fmt.Fprintf(w, "Simulation truncated: %s; results are partial\n", r.Truncated)
+	}
+	if opts.Trace && len(r.Trace) > 0 {
+		outputTrace(w, r.Trace)
+	}
+}
+
+// sortKeys are the orders the schedule table can be sorted into. An empty key
+// keeps the order the scheduler produced the rows in.
+var sortKeys = map[string]func(rows []sim.Row) func(i, j int) bool{
+	// pid groups each process's slices together in dispatch order.
+	"pid": func(rows []sim.Row) func(i, j int) bool {
+		return func(i, j int) bool {
+			if rows[i].PID != rows[j].PID {
+				return rows[i].PID < rows[j].PID
+			}
+			return rows[i].Start() < rows[j].Start()
+		}
+	},
+	// completion orders processes by when they finally left the system,
+	// keeping each process's slices together in dispatch order.
+	"completion": func(rows []sim.Row) func(i, j int) bool {
+		finished := make(map[int64]int64)
+		for _, r := range rows {
+			if r.Exit > finished[r.PID] {
+				finished[r.PID] = r.Exit
+			}
+		}
+		return func(i, j int) bool {
+			if fi, fj := finished[rows[i].PID], finished[rows[j].PID]; fi != fj {
+				return fi < fj
+			}
+			if rows[i].PID != rows[j].PID {
+				return rows[i].PID < rows[j].PID
+			}
+			return rows[i].Start() < rows[j].Start()
+		}
+	},
+	// dispatch follows the Gantt chart from left to right.
+	"dispatch": func(rows []sim.Row) func(i, j int) bool {
+		return func(i, j int) bool {
+			return rows[i].Start() < rows[j].Start()
+		}
+	},
+}
+
+func CheckSortKey(key string) error {
+	if _, ok := sortKeys[key]; key != "" && !ok {
+		return fmt.Errorf("%w: unknown sort key %q", sim.ErrInvalidArgs, key)
+	}
+
+	return nil
+}
+
+// SortRows sorts rows in place by one of the sortKeys.
+func SortRows(rows []sim.Row, key string) {
+	if less, ok := sortKeys[key]; ok {
+		sort.SliceStable(rows, less(rows))
+	}
+}
+
+// Title outputs title between rules, heading one algorithm's output or a
+// report.
+func Title(w io.Writer, title string) {
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+}
+
+// Gantt outputs gantt under heading, with one cell per slice or, when a
+// ruler is set, to scale.
+func Gantt(w io.Writer, heading string, gantt []sim.TimeSlice, opts Options) {
+	if opts.RulerMajor > 0 {
+		outputScaledGantt(w, heading, gantt, opts)
+		return
+	}
+	annotate := opts.Annotate
+	_, _ = fmt.Fprintln(w, heading)
+	_, _ = fmt.Fprint(w, "|")
+	for i := range gantt {
+		pid := strconv.FormatInt(gantt[i].PID, 10)
+		if annotate && gantt[i].Note != "" {
+			pid += " " + gantt[i].Note
+		}
+		var padding string
+		if len(pid) < 8 {
+			padding = strings.Repeat(" ", (8-len(pid))/2)
+		}
+		_, _ = io.WriteString(w, padding+pid+padding+"|")
+	}
+	_, _ = fmt.Fprintln(w)
+	for i := range gantt {
+		_, _ = io.WriteString(w, strconv.FormatInt(gantt[i].Start, 10)+"\t")
+		if len(gantt)-1 == i {
+			_, _ = io.WriteString(w, strconv.FormatInt(gantt[i].Stop, 10))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "\n\n")
+}
+
+// outputScaledGantt outputs a Gantt chart whose cells are as wide as the
+// slices are long, over a time axis ruler. Labels that don't fit in their
+// cell, or would run into the previous label, are left out.
+func outputScaledGantt(w io.Writer, heading string, gantt []sim.TimeSlice, opts Options) {
+	resolution := opts.Resolution
+	if resolution < 1 {
+		resolution = 1
+	}
+	var end int64
+	for _, g := range gantt {
+		if g.Stop > end {
+			end = g.Stop
+		}
+	}
+	width := end/resolution + 1
+
+	bar := []byte(strings.Repeat(" ", int(width)))
+	for _, g := range gantt {
+		start, stop := g.Start/resolution, g.Stop/resolution
+		bar[start], bar[stop] = '|', '|'
+		label := fmt.Sprint(g.PID)
+		if opts.Annotate && g.Note != "" {
+			label += " " + g.Note
+		}
+		if room := stop - start - 1; int64(len(label)) <= room {
+			copy(bar[start+1+(room-int64(len(label)))/2:], label)
+		}
+	}
+
+	// A column covers [t, t+resolution), so it gets a tick if a multiple of
+	// the interval falls within it.
+	ticks := func(t, interval int64) bool {
+		return interval > 0 && (t+interval-1)/interval*interval < t+resolution
+	}
+	ruler := make([]byte, width)
+	for c := range ruler {
+		t := int64(c) * resolution
+		switch {
+		case ticks(t, opts.RulerMajor):
+			ruler[c] = '+'
+		case ticks(t, opts.RulerMinor):
+			ruler[c] = '\''
+		default:
+			ruler[c] = '-'
+		}
+	}
+	labels := []byte(strings.Repeat(" ", int(width)))
+	free := 0
+	for t := int64(0); t <= end; t += opts.RulerMajor {
+		label := fmt.Sprint(t)
+		c := int(t / resolution)
+		if c < free {
+			continue
+		}
+		if c+len(label) > len(labels) {
+			labels = append(labels, strings.Repeat(" ", c+len(label)-len(labels))...)
+		}
+		copy(labels[c:], label)
+		free = c + len(label) + 1
+	}
+
+	_, _ = fmt.Fprintln(w, heading)
+	_, _ = fmt.Fprintf(w, "%s\n%s\n%s\n", bar, ruler, strings.TrimRight(string(labels), " "))
+	if resolution > 1 {
+		_, _ = fmt.Fprintf(w, "(1 character = %d time units)\n", resolution)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+func outputTrace(w io.Writer, trace []sim.TraceEvent) {
+	_, _ = fmt.Fprintln(w, "Trace")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Time", "ID", "Event", "Detail"})
+	for _, e := range trace {
+		var pid string
+		if e.PID != 0 {
+			pid = fmt.Sprint(e.PID)
+		}
+		table.Append([]string{fmt.Sprint(e.Time), pid, e.Event, e.Detail})
+	}
+	table.Render()
+}
+
+// outputUtilization outputs the fraction of the schedule's length for which
+// the CPU and the secondary device were each busy.
+func outputUtilization(w io.Writer, r sim.Result) {
+	var span, cpu, device int64
+	for _, g := range r.Gantt {
+		cpu += g.Stop - g.Start
+		if g.Stop > span {
+			span = g.Stop
+		}
+	}
+	for _, g := range r.DeviceGantt {
+		device += g.Stop - g.Start
+		if g.Stop > span {
+			span = g.Stop
+		}
+	}
+	if span == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Utilization: CPU %.1f%%, device %.1f%%\n\n",
+		100*float64(cpu)/float64(span), 100*float64(device)/float64(span))
+}
+
+// exitCell formats a process's exit time, flagging processes that were
+// terminated early by their kill time.
+func exitCell(exit int64, killed bool) string {
+	if killed {
+		return strconv.FormatInt(exit, 10) + " (killed)"
+	}
+
+	return strconv.FormatInt(exit, 10)
+}
+
+// processCells returns the CPU share and slowdown cells for each row. They
+// are only filled in on a process's final row, where it leaves the system.
+//
+// CPU share is the fraction of all CPU time used that went to the process.
+// Slowdown is the process's time in the system over the CPU time it
+// received, which for a process that ran to completion is its turnaround
+// relative to running alone.
+func processCells(rows []sim.Row) [][2]string {
+	type process struct {
+		cpu  int64
+		last int
+	}
+	var busy int64
+	index := make(map[int64]int, len(rows))
+	processes := make([]process, 0, len(rows))
+	for i, r := range rows {
+		busy += r.Burst
+		k, ok := index[r.PID]
+		if !ok {
+			k = len(processes)
+			index[r.PID] = k
+			processes = append(processes, process{last: i})
+		}
+		p := &processes[k]
+		p.cpu += r.Burst
+		if r.Exit >= rows[p.last].Exit {
+			p.last = i
+		}
+	}
+
+	cells := make([][2]string, len(rows))
+	for _, p := range processes {
+		r := rows[p.last]
+		cells[p.last] = [2]string{"-", "-"}
+		if busy > 0 {
+			cells[p.last][0] = strconv.FormatFloat(100*float64(p.cpu)/float64(busy), 'f', 1, 64) + "%"
+		}
+		if p.cpu > 0 {
+			cells[p.last][1] = strconv.FormatFloat(float64(r.Exit-r.Arrival)/float64(p.cpu), 'f', 2, 64)
+		}
+	}
+
+	return cells
+}
+
+func outputSchedule(w io.Writer, rows []sim.Row, extra []string, stats sim.Stats) {
+	_, _ = fmt.Fprintln(w, "Schedule table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(append([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit", "CPU share", "Slowdown"}, extra...))
+	cells := processCells(rows)
+	for i, r := range rows {
+		row := []string{
+			strconv.FormatInt(r.PID, 10),
+			strconv.FormatInt(r.Priority, 10),
+			strconv.FormatInt(r.Burst, 10),
+			strconv.FormatInt(r.Arrival, 10),
+			strconv.FormatInt(r.Wait, 10),
+			strconv.FormatInt(r.Turnaround, 10),
+			exitCell(r.Exit, r.Killed),
+			cells[i][0],
+			cells[i][1],
+		}
+		for j := range extra {
+			var cell string
+			if j < len(r.Extra) {
+				cell = r.Extra[j]
+			}
+			row = append(row, cell)
+		}
+		table.Append(row)
+	}
+	table.SetFooter(append([]string{"", "", "", "",
+		fmt.Sprintf("Average\n%.2f", stats.AveWait),
+		fmt.Sprintf("Average\n%.2f", stats.AveTurnaround),
+		fmt.Sprintf("Throughput\n%.2f/t", stats.Throughput), "", ""}, make([]string, len(extra))...))
+	table.Render()
+}
+
+// outputSeries outputs completions per window with a bar for each, so
+// bursts and warm-up stand out.
+func outputSeries(w io.Writer, series []sim.Window) {
+	_, _ = fmt.Fprintln(w, "Throughput over time")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Window", "Completions", "Throughput", ""})
+	for _, win := range series {
+		table.Append([]string{
+			fmt.Sprintf("%d-%d", win.Start, win.Stop),
+			fmt.Sprint(win.Completions),
+			fmt.Sprintf("%.2f/t", win.Throughput),
+			strings.Repeat("#", win.Completions),
+		})
+	}
+	table.Render()
+}
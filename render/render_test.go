@@ -0,0 +1,131 @@
+package render
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonuorah26/CSCE4600-Project1/sim"
+)
+
+func TestResult(t *testing.T) {
+	r := sim.Result{
+		Rows: []sim.Row{
+			{PID: 1, Burst: 3, Exit: 3},
+			{PID: 2, Burst: 2, Arrival: 1, Wait: 2, Turnaround: 4, Exit: 5},
+		},
+		Gantt:     []sim.TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}},
+		Trace:     []sim.TraceEvent{{Time: 0, PID: 1, Event: "dispatch"}},
+		Truncated: "stopped at time 5 (-max-time)",
+	}
+	tests := []struct {
+		name    string
+		opts    Options
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "plain",
+			want:    []string{"Gantt schedule\n|   1   |   2   |\n0\t3\t5\n", "Simulation truncated: stopped at time 5 (-max-time); results are partial"},
+			notWant: []string{"Trace"},
+		},
+		{
+			name: "to scale with trace",
+			opts: Options{RulerMajor: 5, Trace: true},
+			want: []string{"|1 |2|\n+----+\n0    5\n", "Trace"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			Result(&out, "FCFS", r, tt.opts)
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output lacks %q:\n%s", want, out.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out.String(), notWant) {
+					t.Errorf("output has %q:\n%s", notWant, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestExitCell(t *testing.T) {
+	if got := exitCell(7, false); got != "7" {
+		t.Errorf("got %q, want %q", got, "7")
+	}
+	if got := exitCell(7, true); got != "7 (killed)" {
+		t.Errorf("got %q, want %q", got, "7 (killed)")
+	}
+}
+
+func TestProcessCells(t *testing.T) {
+	tests := []struct {
+		name string
+		rows []sim.Row
+		want [][2]string
+	}{
+		{
+			name: "one row per process",
+			rows: []sim.Row{
+				{PID: 1, Burst: 3, Arrival: 0, Exit: 3},
+				{PID: 2, Burst: 1, Arrival: 1, Exit: 4},
+			},
+			want: [][2]string{{"75.0%", "1.00"}, {"25.0%", "3.00"}},
+		},
+		{
+			name: "only the final slice is filled in",
+			rows: []sim.Row{
+				{PID: 1, Burst: 1, Arrival: 0, Exit: 1},
+				{PID: 2, Burst: 2, Arrival: 1, Exit: 3},
+				{PID: 1, Burst: 1, Arrival: 0, Exit: 4},
+			},
+			want: [][2]string{{}, {"50.0%", "1.00"}, {"50.0%", "2.00"}},
+		},
+		{
+			name: "killed before running",
+			rows: []sim.Row{
+				{PID: 1, Burst: 2, Arrival: 0, Exit: 2},
+				{PID: 2, Burst: 0, Arrival: 1, Exit: 1, Killed: true},
+			},
+			want: [][2]string{{"100.0%", "1.00"}, {"0.0%", "-"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := processCells(tt.rows); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRuler(t *testing.T) {
+	tests := []struct {
+		in           string
+		major, minor int64
+		ok           bool
+	}{
+		{in: "10", major: 10, ok: true},
+		{in: "10,2", major: 10, minor: 2, ok: true},
+		{in: "0"},
+		{in: "10,-1"},
+		{in: "x,2"},
+	}
+	for _, tt := range tests {
+		major, minor, err := ParseRuler(tt.in)
+		if !tt.ok {
+			if !errors.Is(err, sim.ErrInvalidArgs) {
+				t.Errorf("ParseRuler(%q): got %v, want ErrInvalidArgs", tt.in, err)
+			}
+			continue
+		}
+		if err != nil || major != tt.major || minor != tt.minor {
+			t.Errorf("ParseRuler(%q) = %d, %d, %v, want %d, %d", tt.in, major, minor, err, tt.major, tt.minor)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+	"github.com/olekukonko/tablewriter"
+)
+
+// maxHyperperiod caps how far a periodic task set is simulated when its
+// periods have an impractically large least common multiple.
+const maxHyperperiod = 1_000_000
+
+// Task is a periodic task released every Period time units, each job needing
+// WCET units of CPU within Deadline units of its release. Lower Priority
+// numbers run first, as for processes.
+type Task struct {
+	ID       int64
+	WCET     int64
+	Period   int64
+	Deadline int64
+	Priority int64
+}
+
+// loadTasks reads periodic tasks from CSV rows of ID, WCET, period, priority
+// and an optional relative deadline, which defaults to the period.
+func loadTasks(r io.Reader) ([]Task, error) {
+	reader := csv.NewReader(r)
+	// The deadline column may be given for some tasks and not others.
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV", err)
+	}
+
+	tasks := make([]Task, len(rows))
+	for i := range rows {
+		if len(rows[i]) < 4 {
+			return nil, fmt.Errorf("%w: task row %d needs ID, WCET, period and priority", ErrInvalidArgs, i+1)
+		}
+		tasks[i].ID = mustStrToInt(rows[i][0])
+		tasks[i].WCET = mustStrToInt(rows[i][1])
+		tasks[i].Period = mustStrToInt(rows[i][2])
+		tasks[i].Priority = mustStrToInt(rows[i][3])
+		tasks[i].Deadline = tasks[i].Period
+		if len(rows[i]) >= 5 {
+			tasks[i].Deadline = mustStrToInt(rows[i][4])
+		}
+		t := tasks[i]
+		if t.WCET <= 0 || t.Period <= 0 || t.Deadline <= 0 || t.Deadline > t.Period {
+			return nil, fmt.Errorf("%w: task %d needs a positive WCET and period and a deadline no later than its period", ErrInvalidArgs, t.ID)
+		}
+	}
+
+	return tasks, nil
+}
+
+// ResponseTimes returns each task's worst-case response time by iterative
+// response-time analysis: R = C + Σ ⌈R/Tj⌉·Cj over the tasks j that can
+// delay it, starting from R = C until R stops changing. Tasks of equal
+// priority are assumed to delay each other, so the bound is safe whichever
+// one the scheduler picks. The second result reports whether the bound is
+// within the task's deadline; once it isn't, iteration stops and the bound
+// is only known to exceed the deadline.
+func ResponseTimes(tasks []Task) ([]int64, []bool) {
+	bounds := make([]int64, len(tasks))
+	met := make([]bool, len(tasks))
+	for i, t := range tasks {
+		r := t.WCET
+		for r <= t.Deadline {
+			next := t.WCET
+			for j, u := range tasks {
+				if j != i && u.Priority <= t.Priority {
+					next += (r + u.Period - 1) / u.Period * u.WCET
+				}
+			}
+			if next == r {
+				met[i] = true
+				break
+			}
+			r = next
+		}
+		bounds[i] = r
+	}
+
+	return bounds, met
+}
+
+// releaseJobs expands tasks into one process per job released before
+// horizon, all tasks first releasing at time 0 (the critical instant). It
+// also returns which task each job's process ID belongs to.
+func releaseJobs(tasks []Task, horizon int64) ([]Process, map[int64]int) {
+	var (
+		jobs   []Process
+		taskOf = make(map[int64]int)
+	)
+	for i, t := range tasks {
+		for release := int64(0); release < horizon; release += t.Period {
+			pid := int64(len(jobs) + 1)
+			jobs = append(jobs, Process{
+				ProcessID:     pid,
+				ArrivalTime:   release,
+				BurstDuration: t.WCET,
+				Priority:      t.Priority,
+			})
+			taskOf[pid] = i
+		}
+	}
+
+	return jobs, taskOf
+}
+
+// hyperperiod is the least common multiple of the task periods, capped at
+// maxHyperperiod.
+func hyperperiod(tasks []Task) int64 {
+	h := int64(1)
+	for _, t := range tasks {
+		a, b := h, t.Period
+		for b != 0 {
+			a, b = b, a%b
+		}
+		h = h / a * t.Period
+		if h > maxHyperperiod {
+			return maxHyperperiod
+		}
+	}
+
+	return h
+}
+
+// RTAReport outputs each task's analytical worst-case response time next to
+// the longest response seen when simulating the task set under preemptive
+// fixed-priority scheduling over one hyperperiod.
+func RTAReport(ctx context.Context, w io.Writer, tasks []Task, limits Limits) {
+	bounds, met := ResponseTimes(tasks)
+
+	horizon := hyperperiod(tasks)
+	jobs, taskOf := releaseJobs(tasks, horizon)
+	sim := Scheduler{Schedule: ThresholdPrioritySchedule(0)}.Run(ctx, jobs, limits)
+
+	// Rows are per slice, so a job's response is its final exit less its
+	// release.
+	exits := make(map[int64]int64)
+	for _, row := range sim.Rows {
+		if row.Exit > exits[row.PID] {
+			exits[row.PID] = row.Exit
+		}
+	}
+	worst := make([]int64, len(tasks))
+	finished := make([]int, len(tasks))
+	for _, j := range jobs {
+		exit, ok := exits[j.ProcessID]
+		if !ok {
+			continue
+		}
+		i := taskOf[j.ProcessID]
+		finished[i]++
+		if exit-j.ArrivalTime > worst[i] {
+			worst[i] = exit - j.ArrivalTime
+		}
+	}
+
+	render.Title(w, fmt.Sprintf("Response-time analysis (simulated over %d time units)", horizon))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "WCET", "Period", "Deadline", "Priority", "RTA bound", "Simulated max", "Schedulable"})
+	for i, t := range tasks {
+		bound := fmt.Sprint(bounds[i])
+		schedulable := "yes"
+		if !met[i] {
+			bound = fmt.Sprintf("> %d", t.Deadline)
+			schedulable = "no"
+		}
+		simulated := "-"
+		if finished[i] > 0 {
+			simulated = fmt.Sprint(worst[i])
+		}
+		table.Append([]string{
+			fmt.Sprint(t.ID),
+			fmt.Sprint(t.WCET),
+			fmt.Sprint(t.Period),
+			fmt.Sprint(t.Deadline),
+			fmt.Sprint(t.Priority),
+			bound,
+			simulated,
+			schedulable,
+		})
+	}
+	table.Render()
+	if sim.Truncated != "" {
+		_, _ = fmt.Fprintf(w, "Simulation truncated: %s; simulated maxima are partial\n", sim.Truncated)
+	}
+}
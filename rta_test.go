@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResponseTimes(t *testing.T) {
+	tests := []struct {
+		name   string
+		tasks  []Task
+		bounds []int64
+		met    []bool
+	}{
+		{
+			// The textbook set: the lowest priority task's bound lands
+			// exactly on its deadline.
+			name: "schedulable",
+			tasks: []Task{
+				{ID: 1, WCET: 3, Period: 7, Deadline: 7, Priority: 1},
+				{ID: 2, WCET: 3, Period: 12, Deadline: 12, Priority: 2},
+				{ID: 3, WCET: 5, Period: 20, Deadline: 20, Priority: 3},
+			},
+			bounds: []int64{3, 6, 20},
+			met:    []bool{true, true, true},
+		},
+		{
+			name: "deadline missed",
+			tasks: []Task{
+				{ID: 1, WCET: 3, Period: 7, Deadline: 7, Priority: 1},
+				{ID: 2, WCET: 3, Period: 12, Deadline: 12, Priority: 2},
+				{ID: 3, WCET: 5, Period: 20, Deadline: 19, Priority: 3},
+			},
+			bounds: []int64{3, 6, 20},
+			met:    []bool{true, true, false},
+		},
+		{
+			name: "equal priorities delay each other",
+			tasks: []Task{
+				{ID: 1, WCET: 2, Period: 10, Deadline: 10, Priority: 1},
+				{ID: 2, WCET: 3, Period: 10, Deadline: 10, Priority: 1},
+			},
+			bounds: []int64{5, 5},
+			met:    []bool{true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bounds, met := ResponseTimes(tt.tasks)
+			if !reflect.DeepEqual(bounds, tt.bounds) || !reflect.DeepEqual(met, tt.met) {
+				t.Errorf("got %v %v, want %v %v", bounds, met, tt.bounds, tt.met)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+	"github.com/jonuorah26/CSCE4600-Project1/sim"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultTolerance is how far an actual metric may be from its expected value
+// when an expectation doesn't give its own tolerance.
+const defaultTolerance = 0.005
+
+type (
+	// Scenario is a self-contained test case: a workload, the parameters to
+	// schedule it with and the metrics each algorithm is expected to reach.
+	Scenario struct {
+		Name      string        `json:"name" yaml:"name"`
+		Params    Params        `json:"params" yaml:"params"`
+		Processes []sim.Record  `json:"processes" yaml:"processes"`
+		Expect    []Expectation `json:"expect" yaml:"expect"`
+	}
+	// Expectation holds the expected aggregate metrics of one algorithm,
+	// named by the title it is reported under. Metrics left out aren't
+	// checked.
+	Expectation struct {
+		Algorithm  string   `json:"algorithm" yaml:"algorithm"`
+		Wait       *float64 `json:"wait" yaml:"wait"`
+		Turnaround *float64 `json:"turnaround" yaml:"turnaround"`
+		Throughput *float64 `json:"throughput" yaml:"throughput"`
+		Tolerance  *float64 `json:"tolerance" yaml:"tolerance"`
+	}
+)
+
+// LoadScenario reads a scenario bundle, as JSON if the file ends in .json
+// and as YAML otherwise.
+func LoadScenario(path string) (Scenario, error) {
+	sc := Scenario{Params: Params{Seed: 1, CPUs: 1, Placement: "jsq"}}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return sc, fmt.Errorf("%v: error opening scenario file", err)
+	}
+	defer f.Close()
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.NewDecoder(f).Decode(&sc)
+	} else {
+		err = yaml.NewDecoder(f).Decode(&sc)
+	}
+	if err != nil {
+		return sc, fmt.Errorf("%w: reading scenario %s", err, path)
+	}
+	if err := checkPlacement(sc.Params.Placement); err != nil {
+		return sc, fmt.Errorf("%w in scenario %s", err, path)
+	}
+	if sc.Name == "" {
+		sc.Name = filepath.Base(path)
+	}
+
+	return sc, nil
+}
+
+// CheckScenario runs the scenario bundle at path and outputs a table of
+// every expected metric next to the actual one. It reports whether they all
+// matched within tolerance.
+func CheckScenario(ctx context.Context, w io.Writer, path string) (bool, error) {
+	sc, err := LoadScenario(path)
+	if err != nil {
+		return false, err
+	}
+
+	// A bundle's expectations only mean something for a valid workload, so
+	// it is checked as written rather than repaired.
+	processes, _, err := validateWorkload(sim.Processes(sc.Processes), 0, false)
+	if err != nil {
+		return false, err
+	}
+	processes, err = transformWorkload(processes, sc.Params)
+	if err != nil {
+		return false, err
+	}
+	algos := buildSchedulers(processes, sc.Params)
+	limits := sc.Params.Limits()
+
+	var (
+		rows   [][]string
+		checks int
+		passed int
+	)
+	for _, e := range sc.Expect {
+		var algo *Scheduler
+		for i := range algos {
+			if strings.EqualFold(algos[i].Title, e.Algorithm) {
+				algo = &algos[i]
+				break
+			}
+		}
+		if algo == nil {
+			return false, fmt.Errorf("%w: scenario %s expects unknown algorithm %q", ErrInvalidArgs, sc.Name, e.Algorithm)
+		}
+
+		tolerance := defaultTolerance
+		if e.Tolerance != nil {
+			tolerance = *e.Tolerance
+		}
+		stats := algo.Run(ctx, processes, limits).Stats
+		for _, m := range []struct {
+			name     string
+			expected *float64
+			actual   float64
+		}{
+			{"Wait", e.Wait, stats.AveWait},
+			{"Turnaround", e.Turnaround, stats.AveTurnaround},
+			{"Throughput", e.Throughput, stats.Throughput},
+		} {
+			if m.expected == nil {
+				continue
+			}
+			result := "FAIL"
+			if math.Abs(m.actual-*m.expected) <= tolerance {
+				result = "PASS"
+				passed++
+			}
+			checks++
+			rows = append(rows, []string{
+				algo.Title,
+				m.name,
+				fmt.Sprintf("%.2f ± %g", *m.expected, tolerance),
+				fmt.Sprintf("%.2f", m.actual),
+				result,
+			})
+		}
+	}
+
+	render.Title(w, sc.Name)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Metric", "Expected", "Actual", "Result"})
+	table.AppendBulk(rows)
+	table.SetFooter([]string{"", "", "", "Passed", fmt.Sprintf("%d/%d", passed, checks)})
+	table.Render()
+
+	return passed == checks, nil
+}
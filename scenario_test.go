@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestLoadScenarioChecksPlacement(t *testing.T) {
+	tests := []struct {
+		name   string
+		bundle string
+		ok     bool
+	}{
+		{name: "default", bundle: `{"name":"ok","params":{"cpus":2}}`, ok: true},
+		{name: "known", bundle: `{"name":"ok","params":{"cpus":2,"placement":"jiq"}}`, ok: true},
+		{name: "unknown", bundle: `{"name":"bad","params":{"cpus":2,"placement":"foo"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := t.TempDir() + "/scenario.json"
+			if err := os.WriteFile(path, []byte(tt.bundle), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadScenario(path)
+			if tt.ok && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("got %v, want ErrInvalidArgs", err)
+			}
+		})
+	}
+}
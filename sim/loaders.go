@@ -0,0 +1,268 @@
+package sim
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Loader parses a workload of processes from an input stream.
+type Loader interface {
+	Load(r io.Reader) ([]Process, error)
+}
+
+// LoaderFunc adapts an ordinary function to the Loader interface.
+type LoaderFunc func(r io.Reader) ([]Process, error)
+
+func (f LoaderFunc) Load(r io.Reader) ([]Process, error) {
+	return f(r)
+}
+
+var (
+	loaders    = make(map[string]Loader)
+	loaderExts = make(map[string]string)
+)
+
+func init() {
+	RegisterLoader("csv", LoaderFunc(loadCSVProcesses), ".csv")
+	RegisterLoader("json", LoaderFunc(loadJSONProcesses), ".json")
+	RegisterLoader("yaml", LoaderFunc(loadYAMLProcesses), ".yaml", ".yml")
+	RegisterLoader("perf", LoaderFunc(loadPerfTrace), ".perf", ".timehist")
+}
+
+// RegisterLoader makes a Loader available under name and, optionally, as the
+// default for files with any of the given extensions. Registering a name or
+// extension twice replaces the earlier registration. A program embedding the
+// simulator registers its own formats from an init function, before any
+// workload is loaded; the registry isn't safe for concurrent use.
+func RegisterLoader(name string, l Loader, exts ...string) {
+	loaders[name] = l
+	for _, ext := range exts {
+		loaderExts[strings.ToLower(ext)] = name
+	}
+}
+
+// LoaderFor returns the Loader registered as format or, when format is empty,
+// the one registered for path's extension. Files with an unknown extension
+// are read as CSV.
+func LoaderFor(format, path string) (Loader, error) {
+	if format == "" {
+		var ok bool
+		if format, ok = loaderExts[strings.ToLower(filepath.Ext(path))]; !ok {
+			format = "csv"
+		}
+	}
+	l, ok := loaders[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown input format %q", ErrInvalidArgs, format)
+	}
+
+	return l, nil
+}
+
+// Record is the shape of one process in JSON and YAML workloads.
+type Record struct {
+	ID       int64 `json:"id" yaml:"id"`
+	Burst    int64 `json:"burst" yaml:"burst"`
+	Arrival  int64 `json:"arrival" yaml:"arrival"`
+	Priority int64 `json:"priority" yaml:"priority"`
+	Kill     int64 `json:"kill" yaml:"kill"`
+	Device   int64 `json:"device" yaml:"device"`
+}
+
+// Process returns the process r describes.
+func (r Record) Process() Process {
+	return Process{
+		ProcessID:     r.ID,
+		ArrivalTime:   r.Arrival,
+		BurstDuration: r.Burst,
+		Priority:      r.Priority,
+		KillTime:      r.Kill,
+		DeviceTime:    r.Device,
+	}
+}
+
+// Processes returns the processes records describe.
+func Processes(records []Record) []Process {
+	processes := make([]Process, len(records))
+	for i, r := range records {
+		processes[i] = r.Process()
+	}
+
+	return processes
+}
+
+// loadCSVProcesses reads processes from CSV rows of ID, burst, arrival and
+// the optional priority, kill time and device time. Rows are parsed as they
+// are read rather than all held as strings first.
+func loadCSVProcesses(r io.Reader) ([]Process, error) {
+	reader := csv.NewReader(bufio.NewReader(r))
+	reader.ReuseRecord = true
+	// Only the first three columns are required; rows may differ in how
+	// many of the optional ones they give.
+	reader.FieldsPerRecord = -1
+
+	var processes []Process
+	for line := 1; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading CSV", err)
+		}
+		if len(row) < 3 {
+			return nil, fmt.Errorf("%w: CSV row %d needs ID, burst and arrival", ErrInvalidArgs, line)
+		}
+		if len(row) > 6 {
+			row = row[:6]
+		}
+
+		var values [6]int64
+		for i, field := range row {
+			if values[i], err = strconv.ParseInt(field, 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: CSV row %d: %v", ErrInvalidArgs, line, err)
+			}
+		}
+		processes = append(processes, Process{
+			ProcessID:     values[0],
+			BurstDuration: values[1],
+			ArrivalTime:   values[2],
+			Priority:      values[3],
+			KillTime:      values[4],
+			DeviceTime:    values[5],
+		})
+	}
+
+	return processes, nil
+}
+
+// loadJSONProcesses reads a JSON array of process objects, decoding them one
+// at a time.
+func loadJSONProcesses(r io.Reader) ([]Process, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	if tok, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("%w: reading JSON", err)
+	} else if tok != json.Delim('[') {
+		return nil, fmt.Errorf("%w: JSON workload must be an array of processes", ErrInvalidArgs)
+	}
+
+	var processes []Process
+	for dec.More() {
+		var record Record
+		if err := dec.Decode(&record); err != nil {
+			return nil, fmt.Errorf("%w: reading JSON", err)
+		}
+		processes = append(processes, record.Process())
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("%w: reading JSON", err)
+	}
+
+	return processes, nil
+}
+
+// loadYAMLProcesses reads a YAML sequence of process mappings.
+func loadYAMLProcesses(r io.Reader) ([]Process, error) {
+	var records []Record
+	if err := yaml.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("%w: reading YAML", err)
+	}
+
+	return Processes(records), nil
+}
+
+// loadPerfTrace builds a workload from the output of `perf sched timehist`.
+// Each task becomes one process: its arrival is the start of its first run
+// less the scheduling delay it saw, and its burst is the sum of its run
+// times. All times are converted to whole milliseconds from the first
+// arrival in the trace.
+func loadPerfTrace(r io.Reader) ([]Process, error) {
+	type task struct {
+		arrival float64
+		run     float64
+	}
+	tasks := make(map[int64]*task)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		// Skip the header rows, which don't start with a timestamp.
+		ts, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		name := fields[len(fields)-4]
+		if strings.HasPrefix(name, "<idle>") {
+			continue
+		}
+		tid, err := perfTaskID(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: perf trace line %d", err, line)
+		}
+		delay, err := strconv.ParseFloat(fields[len(fields)-2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: perf trace line %d", err, line)
+		}
+		run, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: perf trace line %d", err, line)
+		}
+
+		// Timestamps are in seconds, the delay and run columns in milliseconds.
+		t, ok := tasks[tid]
+		if !ok {
+			t = &task{arrival: ts*1000 - run - delay}
+			tasks[tid] = t
+		}
+		t.run += run
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading perf trace", err)
+	}
+
+	tids := make([]int64, 0, len(tasks))
+	first := math.Inf(1)
+	for tid, t := range tasks {
+		tids = append(tids, tid)
+		first = math.Min(first, t.arrival)
+	}
+	sort.Slice(tids, func(i, j int) bool {
+		return tasks[tids[i]].arrival < tasks[tids[j]].arrival
+	})
+
+	processes := make([]Process, len(tids))
+	for i, tid := range tids {
+		processes[i] = Process{
+			ProcessID:     tid,
+			ArrivalTime:   int64(math.Round(tasks[tid].arrival - first)),
+			BurstDuration: int64(math.Max(1, math.Round(tasks[tid].run))),
+		}
+	}
+
+	return processes, nil
+}
+
+// perfTaskID extracts the thread ID from a timehist task column such as
+// "gcc[31949]" or "java[8812/8790]".
+func perfTaskID(name string) (int64, error) {
+	open := strings.LastIndexByte(name, '[')
+	if open < 0 || !strings.HasSuffix(name, "]") {
+		return 0, fmt.Errorf("malformed task %q", name)
+	}
+	id, _, _ := strings.Cut(name[open+1:len(name)-1], "/")
+
+	return strconv.ParseInt(id, 10, 64)
+}
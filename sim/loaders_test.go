@@ -0,0 +1,85 @@
+package sim_test
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonuorah26/CSCE4600-Project1/sim"
+)
+
+func TestRegisterLoader(t *testing.T) {
+	// A format from outside the package, one process per line of text.
+	sim.RegisterLoader("lines", sim.LoaderFunc(func(r io.Reader) ([]sim.Process, error) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		var processes []sim.Process
+		for i := range strings.Fields(string(data)) {
+			processes = append(processes, sim.Process{ProcessID: int64(i + 1), BurstDuration: 1})
+		}
+		return processes, nil
+	}), ".lines")
+
+	l, err := sim.LoaderFor("", "workload.LINES")
+	if err != nil {
+		t.Fatal(err)
+	}
+	processes, err := l.Load(strings.NewReader("a b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(processes) != 2 {
+		t.Errorf("got %d processes, want 2", len(processes))
+	}
+
+	if _, err := sim.LoaderFor("xml", ""); !errors.Is(err, sim.ErrInvalidArgs) {
+		t.Errorf("unknown format: got %v, want ErrInvalidArgs", err)
+	}
+}
+
+func TestLoadCSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []sim.Process
+		ok    bool
+	}{
+		{
+			name:  "optional columns vary by row",
+			input: "1,4,0\n2,3,1,2\n3,2,2,1,9,4\n",
+			want: []sim.Process{
+				{ProcessID: 1, BurstDuration: 4},
+				{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1, Priority: 2},
+				{ProcessID: 3, BurstDuration: 2, ArrivalTime: 2, Priority: 1, KillTime: 9, DeviceTime: 4},
+			},
+			ok: true,
+		},
+		{name: "too few columns", input: "1,4\n"},
+		{name: "not a number", input: "1,four,0\n"},
+	}
+	csv, err := sim.LoaderFor("", "workload.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := csv.Load(strings.NewReader(tt.input))
+			if !tt.ok {
+				if !errors.Is(err, sim.ErrInvalidArgs) {
+					t.Errorf("got %v, want ErrInvalidArgs", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Package sim holds the scheduling simulator's workload and result types
+// and the registry of loaders that read workloads, so that programs other
+// than the simulator's command can build workloads, supply their own input
+// formats and work with schedules.
+package sim
+
+import "errors"
+
+// ErrInvalidArgs is wrapped by every error caused by invalid input.
+var ErrInvalidArgs = errors.New("invalid args")
+
+// Process is one process of a workload.
+type Process struct {
+	ProcessID     int64
+	ArrivalTime   int64
+	BurstDuration int64
+	Priority      int64
+	// KillTime is the time at which the process is terminated whether or
+	// not it has finished. Zero means the process is never killed.
+	KillTime int64
+	// DeviceTime is how long the process holds the secondary device
+	// (e.g. a GPU) from when it is dispatched. Zero means it only needs
+	// the CPU.
+	DeviceTime int64
+}
@@ -0,0 +1,76 @@
+package sim
+
+type (
+	// TimeSlice is one stretch of a Gantt chart, PID holding the CPU (or
+	// device) from Start to Stop.
+	TimeSlice struct {
+		PID   int64 `json:"pid"`
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+		// Note annotates the slice's Gantt cell when annotations are on.
+		Note string `json:"note,omitempty"`
+	}
+	// Row is one line of a schedule table: a process's run, or for
+	// preemptive schedulers one of its slices.
+	Row struct {
+		PID        int64 `json:"pid"`
+		Priority   int64 `json:"priority"`
+		Burst      int64 `json:"burst"`
+		Arrival    int64 `json:"arrival"`
+		Wait       int64 `json:"wait"`
+		Turnaround int64 `json:"turnaround"`
+		Exit       int64 `json:"exit"`
+		Killed     bool  `json:"killed,omitempty"`
+		// Extra holds the row's values for the result's ExtraColumns.
+		Extra []string `json:"extra,omitempty"`
+	}
+	// Stats holds the aggregate metrics of a schedule.
+	Stats struct {
+		AveWait       float64 `json:"average_wait"`
+		AveTurnaround float64 `json:"average_turnaround"`
+		Throughput    float64 `json:"throughput"`
+	}
+	// Result is everything a scheduler computes, ready to be rendered.
+	Result struct {
+		Rows []Row `json:"rows"`
+		// ExtraColumns names the scheduler-specific columns that follow the
+		// standard ones in the schedule table.
+		ExtraColumns []string    `json:"extra_columns,omitempty"`
+		Gantt        []TimeSlice `json:"gantt"`
+		// DeviceGantt is the secondary device's schedule, for schedulers
+		// that manage one.
+		DeviceGantt []TimeSlice `json:"device_gantt,omitempty"`
+		// CPUGantts splits Gantt by CPU for multi-CPU runs.
+		CPUGantts [][]TimeSlice `json:"cpu_gantts,omitempty"`
+		Stats     Stats         `json:"stats"`
+		// Trace lists the simulation's events, for schedulers that record
+		// them.
+		Trace []TraceEvent `json:"trace,omitempty"`
+		// Series counts completions per window when a window size is given.
+		Series []Window `json:"throughput_series,omitempty"`
+		// Truncated explains why the simulation was stopped early, if it was.
+		Truncated string `json:"truncated,omitempty"`
+	}
+	// TraceEvent is one step of a simulation: a process arriving, being
+	// dispatched or exiting, or its effective priority changing.
+	TraceEvent struct {
+		Time int64 `json:"time"`
+		// PID is 0 for events that aren't about one process.
+		PID    int64  `json:"pid"`
+		Event  string `json:"event"`
+		Detail string `json:"detail,omitempty"`
+	}
+	// Window is the number of processes that left the system in [Start, Stop).
+	Window struct {
+		Start       int64   `json:"start"`
+		Stop        int64   `json:"stop"`
+		Completions int     `json:"completions"`
+		Throughput  float64 `json:"throughput"`
+	}
+)
+
+// Start is when the row's slice was dispatched, or for a process killed
+// while waiting, when it was killed.
+func (r Row) Start() int64 {
+	return r.Exit - r.Burst
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/jonuorah26/CSCE4600-Project1/render"
+	"github.com/olekukonko/tablewriter"
+)
+
+// SRTFSchedule schedules processes shortest-remaining-time-first, checking for
+// preemption whenever a process arrives or finishes.
+func SRTFSchedule(ctx context.Context, processes []Process, limits Limits) Result {
+	return preemptive(ctx, processes, srtfPolicy(0), limits)
+}
+
+// TickSRTFSchedule returns an SRTF scheduler that, like a timer-driven kernel,
+// only checks for preemption on every tick-th time unit. A process that
+// finishes between ticks is still replaced straight away.
+func TickSRTFSchedule(tick int64) func(ctx context.Context, processes []Process, limits Limits) Result {
+	return func(ctx context.Context, processes []Process, limits Limits) Result {
+		return preemptive(ctx, processes, srtfPolicy(tick), limits)
+	}
+}
+
+func srtfPolicy(tick int64) preemptivePolicy {
+	shorter := func(a, b *job) bool {
+		return a.remaining < b.remaining
+	}
+
+	return preemptivePolicy{better: shorter, preempts: shorter, tick: tick}
+}
+
+// summarize averages the wait and turnaround of rows and derives throughput
+// from the latest exit, the same way the built-in schedulers do.
+func summarize(rows []Row) Stats {
+	var (
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+	)
+	for _, r := range rows {
+		totalWait += float64(r.Wait)
+		totalTurnaround += float64(r.Turnaround)
+		lastCompletion = math.Max(lastCompletion, float64(r.Exit))
+	}
+
+	if len(rows) == 0 {
+		return Stats{}
+	}
+	count := float64(len(rows))
+	return Stats{
+		AveWait:       totalWait / count,
+		AveTurnaround: totalTurnaround / count,
+		Throughput:    count / lastCompletion,
+	}
+}
+
+// contextSwitches counts how often the CPU moves from one process to another
+// in a Gantt chart.
+func contextSwitches(gantt []TimeSlice) int {
+	var switches int
+	for i := 1; i < len(gantt); i++ {
+		if gantt[i].PID != gantt[i-1].PID {
+			switches++
+		}
+	}
+
+	return switches
+}
+
+// TickReport outputs ideal SRTF next to SRTF whose preemption checks happen
+// only every tick time units, showing what the coarser timer costs.
+func TickReport(ctx context.Context, w io.Writer, processes []Process, tick int64, limits Limits) {
+	ideal := Scheduler{Schedule: SRTFSchedule}.Run(ctx, processes, limits)
+	ticked := Scheduler{Schedule: TickSRTFSchedule(tick)}.Run(ctx, processes, limits)
+
+	row := func(name string, r Result) []string {
+		return []string{
+			name,
+			fmt.Sprintf("%.2f", r.Stats.AveWait),
+			fmt.Sprintf("%.2f", r.Stats.AveTurnaround),
+			fmt.Sprintf("%.2f/t", r.Stats.Throughput),
+			fmt.Sprint(contextSwitches(r.Gantt)),
+		}
+	}
+
+	render.Title(w, "SRTF preemption granularity")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Preemption checks", "Wait", "Turnaround", "Throughput", "Context switches"})
+	table.Append(row("every arrival", ideal))
+	table.Append(row(fmt.Sprintf("every %d", tick), ticked))
+	table.SetFooter([]string{"Change",
+		fmt.Sprintf("%+.2f", ticked.Stats.AveWait-ideal.Stats.AveWait),
+		fmt.Sprintf("%+.2f", ticked.Stats.AveTurnaround-ideal.Stats.AveTurnaround),
+		fmt.Sprintf("%+.2f/t", ticked.Stats.Throughput-ideal.Stats.Throughput),
+		fmt.Sprintf("%+d", contextSwitches(ticked.Gantt)-contextSwitches(ideal.Gantt))})
+	table.Render()
+}
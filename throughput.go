@@ -0,0 +1,37 @@
+package main
+
+// throughputSeries splits the schedule of processes into consecutive windows
+// of size time units, from time 0 to the last exit, and counts the processes
+// that completed in each. A process completes at the exit of its final row,
+// and only if its rows add up to its whole burst: processes that were killed,
+// or cut off by a limit, don't count.
+func throughputSeries(rows []Row, processes []Process, size int64) []Window {
+	final := make(map[int64]Row)
+	consumed := make(map[int64]int64)
+	var end int64
+	for _, r := range rows {
+		if f, ok := final[r.PID]; !ok || r.Exit >= f.Exit {
+			final[r.PID] = r
+		}
+		consumed[r.PID] += r.Burst
+		if r.Exit > end {
+			end = r.Exit
+		}
+	}
+
+	series := make([]Window, 0, end/size+1)
+	for start := int64(0); start <= end; start += size {
+		series = append(series, Window{Start: start, Stop: start + size})
+	}
+	for _, p := range processes {
+		r, ok := final[p.ProcessID]
+		if ok && !r.Killed && consumed[p.ProcessID] == p.BurstDuration {
+			series[r.Exit/size].Completions++
+		}
+	}
+	for i := range series {
+		series[i].Throughput = float64(series[i].Completions) / float64(size)
+	}
+
+	return series
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestThroughputSeries(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+		want   []int
+	}{
+		{
+			// Preemptive priority: 1 runs 0-1 and 4-7, 2 runs 1-4, 3 runs
+			// 7-9. Process 1's first slice isn't a completion.
+			name: "run to completion",
+			want: []int{0, 0, 1, 1, 1},
+		},
+		{
+			// Process 1's second slice is cut at 6 and 3 never runs.
+			name:   "cut short",
+			limits: Limits{MaxTime: 6},
+			want:   []int{0, 0, 1, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Scheduler{Schedule: ThresholdPrioritySchedule(0)}.Run(context.Background(), workload, tt.limits)
+			var got []int
+			for _, w := range throughputSeries(r.Rows, workload, 2) {
+				got = append(got, w.Completions)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("completions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThroughputSeriesSkipsKilled(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, KillTime: 3},
+	}
+	rows := []Row{
+		{PID: 1, Burst: 2, Exit: 2},
+		{PID: 2, Burst: 1, Arrival: 1, Exit: 3, Killed: true},
+	}
+	var got []int
+	for _, w := range throughputSeries(rows, processes, 2) {
+		got = append(got, w.Completions)
+	}
+	if want := []int{0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("completions = %v, want %v", got, want)
+	}
+}
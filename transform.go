@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// transformWorkload applies the workload transforms in params to a copy of
+// processes. Bursts and device times are multiplied by ScaleBurst; arrival
+// and kill times, being points on the same timeline, are multiplied by
+// ScaleArrival and then moved by ShiftArrival. Scales of zero leave values
+// as they are, results are rounded to whole time units, no arrival moves
+// before time 0, no kill time moves before its arrival or to 0 (never
+// killed) and no burst scales to nothing.
+func transformWorkload(processes []Process, params Params) ([]Process, error) {
+	if params.ScaleBurst < 0 || params.ScaleArrival < 0 {
+		return nil, fmt.Errorf("%w: scale factors must be positive", ErrInvalidArgs)
+	}
+
+	transformed := copyProcesses(processes)
+	for i := range transformed {
+		p := &transformed[i]
+		if params.ScaleBurst > 0 {
+			p.BurstDuration = scaleDuration(p.BurstDuration, params.ScaleBurst)
+			p.DeviceTime = scaleDuration(p.DeviceTime, params.ScaleBurst)
+		}
+		p.ArrivalTime = moveTime(p.ArrivalTime, params)
+		if p.KillTime > 0 {
+			// A kill time of 0 means never killed, so one moved to or before
+			// time 0 stays in force at the process's arrival, or time 1.
+			p.KillTime = moveTime(p.KillTime, params)
+			if p.KillTime < p.ArrivalTime {
+				p.KillTime = p.ArrivalTime
+			}
+			if p.KillTime < 1 {
+				p.KillTime = 1
+			}
+		}
+	}
+
+	return transformed, nil
+}
+
+// scaleDuration multiplies d by scale, keeping non-zero durations at least
+// one time unit long.
+func scaleDuration(d int64, scale float64) int64 {
+	scaled := int64(math.Round(float64(d) * scale))
+	if d > 0 && scaled < 1 {
+		return 1
+	}
+
+	return scaled
+}
+
+func moveTime(t int64, params Params) int64 {
+	if params.ScaleArrival > 0 {
+		t = int64(math.Round(float64(t) * params.ScaleArrival))
+	}
+	t += params.ShiftArrival
+	if t < 0 {
+		return 0
+	}
+
+	return t
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTransformWorkload(t *testing.T) {
+	tests := []struct {
+		name   string
+		params Params
+		in     Process
+		want   Process
+	}{
+		{
+			name: "no transforms",
+			in:   Process{ProcessID: 1, ArrivalTime: 5, BurstDuration: 10, KillTime: 8},
+			want: Process{ProcessID: 1, ArrivalTime: 5, BurstDuration: 10, KillTime: 8},
+		},
+		{
+			name:   "scale burst and device time",
+			params: Params{ScaleBurst: 0.5},
+			in:     Process{ProcessID: 1, ArrivalTime: 5, BurstDuration: 9, DeviceTime: 1},
+			want:   Process{ProcessID: 1, ArrivalTime: 5, BurstDuration: 5, DeviceTime: 1},
+		},
+		{
+			name:   "scale and shift arrival and kill",
+			params: Params{ScaleArrival: 2, ShiftArrival: 3},
+			in:     Process{ProcessID: 1, ArrivalTime: 5, BurstDuration: 10, KillTime: 8},
+			want:   Process{ProcessID: 1, ArrivalTime: 13, BurstDuration: 10, KillTime: 19},
+		},
+		{
+			name:   "never killed stays so",
+			params: Params{ShiftArrival: 4},
+			in:     Process{ProcessID: 1, ArrivalTime: 5, BurstDuration: 10},
+			want:   Process{ProcessID: 1, ArrivalTime: 9, BurstDuration: 10},
+		},
+		{
+			name:   "kill shifted before time 0 stays in force",
+			params: Params{ShiftArrival: -10},
+			in:     Process{ProcessID: 1, ArrivalTime: 5, BurstDuration: 10, KillTime: 8},
+			want:   Process{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10, KillTime: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transformWorkload([]Process{tt.in}, tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got[0], tt.want) {
+				t.Errorf("got %+v, want %+v", got[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformWorkloadRejectsNegativeScale(t *testing.T) {
+	_, err := transformWorkload(nil, Params{ScaleBurst: -1})
+	if !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("got %v, want ErrInvalidArgs", err)
+	}
+}
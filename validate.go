@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateWorkload checks processes for duplicate IDs, negative bursts,
+// arrivals, kill times and device times, kill times before arrival and, if
+// horizon is positive, arrivals after it. Rows are numbered from 1 in input
+// order. Without fix every problem is reported in one error; with fix a copy
+// of processes is returned in which duplicate IDs are renumbered past the
+// largest ID, negative values are clamped to 0, early kill times to the
+// arrival and late arrivals to the horizon, along with a description of each
+// change.
+func validateWorkload(processes []Process, horizon int64, fix bool) ([]Process, []string, error) {
+	checked := copyProcesses(processes)
+
+	var nextPID int64
+	for _, p := range checked {
+		if p.ProcessID >= nextPID {
+			nextPID = p.ProcessID + 1
+		}
+	}
+
+	var problems []string
+	report := func(row int, format string, args ...any) {
+		problems = append(problems, fmt.Sprintf("row %d: ", row)+fmt.Sprintf(format, args...))
+	}
+	clamp := func(row int, v *int64, limit int64, problem string) {
+		if fix {
+			report(row, "%s clamped to %d", problem, limit)
+			*v = limit
+		} else {
+			report(row, "%s", problem)
+		}
+	}
+	clampNegative := func(row int, name string, v *int64) {
+		if *v < 0 {
+			clamp(row, v, 0, fmt.Sprintf("negative %s %d", name, *v))
+		}
+	}
+
+	first := make(map[int64]int, len(checked))
+	for i := range checked {
+		p, row := &checked[i], i+1
+		if dup, ok := first[p.ProcessID]; ok {
+			if fix {
+				report(row, "duplicate ID %d (first on row %d) renumbered to %d", p.ProcessID, dup, nextPID)
+				p.ProcessID = nextPID
+				nextPID++
+			} else {
+				report(row, "duplicate ID %d (first on row %d)", p.ProcessID, dup)
+			}
+		} else {
+			first[p.ProcessID] = row
+		}
+
+		clampNegative(row, "burst", &p.BurstDuration)
+		clampNegative(row, "arrival", &p.ArrivalTime)
+		clampNegative(row, "kill time", &p.KillTime)
+		clampNegative(row, "device time", &p.DeviceTime)
+		if horizon > 0 && p.ArrivalTime > horizon {
+			clamp(row, &p.ArrivalTime, horizon, fmt.Sprintf("arrival %d after horizon %d", p.ArrivalTime, horizon))
+		}
+		// A kill time of 0 means the process is never killed.
+		if p.KillTime > 0 && p.KillTime < p.ArrivalTime {
+			clamp(row, &p.KillTime, p.ArrivalTime, fmt.Sprintf("kill time %d before arrival %d", p.KillTime, p.ArrivalTime))
+		}
+	}
+
+	if !fix && len(problems) > 0 {
+		return nil, nil, fmt.Errorf("%w: invalid workload (use -fix to repair it):\n  %s", ErrInvalidArgs, strings.Join(problems, "\n  "))
+	}
+
+	return checked, problems, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestValidateWorkload(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []Process
+		horizon  int64
+		problems []string
+		fixed    []Process
+	}{
+		{
+			name:  "valid",
+			in:    []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 2, ArrivalTime: 1}},
+			fixed: []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 2, ArrivalTime: 1}},
+		},
+		{
+			name: "duplicates point at the first row",
+			in:   []Process{{ProcessID: 4, BurstDuration: 1}, {ProcessID: 4, BurstDuration: 1}, {ProcessID: 4, BurstDuration: 1}},
+			problems: []string{
+				"row 2: duplicate ID 4 (first on row 1) renumbered to 5",
+				"row 3: duplicate ID 4 (first on row 1) renumbered to 6",
+			},
+			fixed: []Process{{ProcessID: 4, BurstDuration: 1}, {ProcessID: 5, BurstDuration: 1}, {ProcessID: 6, BurstDuration: 1}},
+		},
+		{
+			name:     "negative values",
+			in:       []Process{{ProcessID: 1, BurstDuration: -2, ArrivalTime: -1}},
+			problems: []string{"row 1: negative burst -2 clamped to 0", "row 1: negative arrival -1 clamped to 0"},
+			fixed:    []Process{{ProcessID: 1}},
+		},
+		{
+			name:     "kill time before arrival",
+			in:       []Process{{ProcessID: 1, BurstDuration: 1, ArrivalTime: 5, KillTime: 3}, {ProcessID: 2, BurstDuration: 1, ArrivalTime: 5}},
+			problems: []string{"row 1: kill time 3 before arrival 5 clamped to 5"},
+			fixed:    []Process{{ProcessID: 1, BurstDuration: 1, ArrivalTime: 5, KillTime: 5}, {ProcessID: 2, BurstDuration: 1, ArrivalTime: 5}},
+		},
+		{
+			name:     "arrival after horizon",
+			in:       []Process{{ProcessID: 1, BurstDuration: 1, ArrivalTime: 20}},
+			horizon:  10,
+			problems: []string{"row 1: arrival 20 after horizon 10 clamped to 10"},
+			fixed:    []Process{{ProcessID: 1, BurstDuration: 1, ArrivalTime: 10}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed, problems, err := validateWorkload(tt.in, tt.horizon, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(problems, tt.problems) {
+				t.Errorf("problems = %q, want %q", problems, tt.problems)
+			}
+			if !reflect.DeepEqual(fixed, tt.fixed) {
+				t.Errorf("fixed = %+v, want %+v", fixed, tt.fixed)
+			}
+
+			_, _, err = validateWorkload(tt.in, tt.horizon, false)
+			if len(tt.problems) == 0 && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(tt.problems) > 0 && !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("got %v, want ErrInvalidArgs", err)
+			}
+		})
+	}
+}
+
+func TestCheckScenarioValidatesWorkload(t *testing.T) {
+	path := t.TempDir() + "/dup.json"
+	bundle := `{"name":"dup","processes":[{"id":1,"burst":2,"arrival":0},{"id":1,"burst":2,"arrival":1}]}`
+	if err := os.WriteFile(path, []byte(bundle), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := CheckScenario(context.Background(), io.Discard, path)
+	if !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("got %v, want ErrInvalidArgs", err)
+	}
+}